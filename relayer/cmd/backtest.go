@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagParams = "params"
+	flagStep   = "step"
+)
+
+type (
+	backtestAssetOutput struct {
+		Denom                 string  `json:"denom"`
+		Relays                int     `json:"relays"`
+		HeartbeatRelays       int     `json:"heartbeat_relays"`
+		DeviationRelays       int     `json:"deviation_relays"`
+		MaxStaleness          string  `json:"max_staleness"`
+		MaxUnrelayedDeviation float64 `json:"max_unrelayed_deviation"`
+		FeeCost               float64 `json:"fee_cost"`
+	}
+
+	backtestOutput struct {
+		Name        string                `json:"name"`
+		Destination string                `json:"destination"`
+		Interval    string                `json:"interval"`
+		Deviation   float64               `json:"deviation"`
+		FeePerRelay float64               `json:"fee_per_relay"`
+		FeeDenom    string                `json:"fee_denom"`
+		Ticks       int                   `json:"ticks"`
+		Relays      int                   `json:"relays"`
+		FeeCost     float64               `json:"fee_cost"`
+		Assets      []backtestAssetOutput `json:"assets"`
+	}
+)
+
+func getBacktestCmd() *cobra.Command {
+	backtestCmd := &cobra.Command{
+		Use:   "backtest [config-file] [prices-file]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Replay a historical price series through the relay policy",
+		Long: `Replay a CSV or JSON price series through the heartbeat and deviation logic
+of the relayer with a simulated clock, and report the number of relays, the max staleness,
+the max unrelayed deviation and the estimated fee cost of each parameter set.
+
+Parameter sets default to the values of the config file and can be overridden with
+one or several --params flags, e.g. --params "interval=1h,deviation=0.01,fee=2000000".`,
+		RunE: backtestCmdHandler,
+	}
+
+	backtestCmd.Flags().StringArray(flagParams, nil,
+		"parameter set to backtest as comma separated key=value pairs (name|destination|interval|deviation|fee)")
+	backtestCmd.Flags().Duration(flagStep, 0,
+		"simulated tick interval; if zero, a tick is simulated at every timestamp of the series")
+	backtestCmd.Flags().String(flagFormat, "text", "Print the report in the given format (text|json)")
+
+	return backtestCmd
+}
+
+func backtestCmdHandler(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfigFromFlags(args[0], "")
+	if err != nil {
+		return err
+	}
+
+	series, err := relayer.LoadPriceSeries(args[1])
+	if err != nil {
+		return err
+	}
+	series, err = filterPriceSeries(series, cfg.Assets)
+	if err != nil {
+		return err
+	}
+
+	rawParams, err := cmd.Flags().GetStringArray(flagParams)
+	if err != nil {
+		return err
+	}
+	step, err := cmd.Flags().GetDuration(flagStep)
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString(flagFormat)
+	if err != nil {
+		return err
+	}
+
+	defaultFee, err := strconv.ParseFloat(cfg.AxelarGas.Default, 64)
+	if err != nil {
+		return fmt.Errorf("invalid axelar_gas default: %w", err)
+	}
+	base := relayer.BacktestParams{
+		Name:        "config",
+		Destination: cfg.Relayer.Destination,
+		Interval:    cfg.Relayer.Interval,
+		Deviation:   cfg.Relayer.Deviation,
+		Fee:         defaultFee,
+		Relayer:     cfg.Relayer,
+		Assets:      cfg.Assets,
+	}
+
+	paramSets := []relayer.BacktestParams{base}
+	if len(rawParams) > 0 {
+		paramSets = make([]relayer.BacktestParams, len(rawParams))
+		for i, raw := range rawParams {
+			params, err := parseBacktestParams(raw, base)
+			if err != nil {
+				return err
+			}
+			if params.Name == base.Name {
+				params.Name = fmt.Sprintf("set-%d", i+1)
+			}
+			paramSets[i] = params
+		}
+	}
+
+	outputs := make([]backtestOutput, len(paramSets))
+	for i, params := range paramSets {
+		report, err := relayer.Backtest(series, params, step)
+		if err != nil {
+			return fmt.Errorf("%s: %w", params.Name, err)
+		}
+		outputs[i] = newBacktestOutput(report, cfg.AxelarGas.Denom)
+	}
+
+	switch format {
+	case "json":
+		bz, err := json.MarshalIndent(outputs, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(bz))
+		return err
+
+	case "text":
+		return printBacktestOutputs(outputs)
+
+	default:
+		return fmt.Errorf("invalid output format: %s", format)
+	}
+}
+
+// filterPriceSeries keeps the observations of the assets in the config.
+func filterPriceSeries(series []relayer.PricePoint, assets []config.Assets) ([]relayer.PricePoint, error) {
+	denoms := map[string]bool{}
+	for _, a := range assets {
+		denoms[a.Denom] = true
+	}
+
+	filtered := []relayer.PricePoint{}
+	for _, p := range series {
+		if denoms[p.Denom] {
+			filtered = append(filtered, p)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("price series has no observations for the configured assets")
+	}
+
+	return filtered, nil
+}
+
+// parseBacktestParams parses a comma separated list of key=value pairs on top
+// of the given base parameters.
+func parseBacktestParams(raw string, base relayer.BacktestParams) (relayer.BacktestParams, error) {
+	params := base
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return params, fmt.Errorf("invalid backtest parameter %q; expected key=value", pair)
+		}
+
+		var err error
+		switch strings.TrimSpace(key) {
+		case "name":
+			params.Name = value
+		case "destination":
+			params.Destination = value
+		case "interval":
+			params.Interval, err = time.ParseDuration(value)
+		case "deviation":
+			params.Deviation, err = strconv.ParseFloat(value, 64)
+		case "fee":
+			params.Fee, err = strconv.ParseFloat(value, 64)
+		default:
+			return params, fmt.Errorf("unknown backtest parameter %q", key)
+		}
+		if err != nil {
+			return params, fmt.Errorf("invalid backtest parameter %q: %w", pair, err)
+		}
+	}
+
+	return params, nil
+}
+
+func newBacktestOutput(report relayer.BacktestReport, feeDenom string) backtestOutput {
+	out := backtestOutput{
+		Name:        report.Params.Name,
+		Destination: report.Params.Destination,
+		Interval:    report.Params.Interval.String(),
+		Deviation:   report.Params.Deviation,
+		FeePerRelay: report.Params.Fee,
+		FeeDenom:    feeDenom,
+		Ticks:       report.Ticks,
+		Relays:      report.Relays,
+		FeeCost:     report.FeeCost,
+		Assets:      make([]backtestAssetOutput, len(report.Assets)),
+	}
+	for i, a := range report.Assets {
+		out.Assets[i] = backtestAssetOutput{
+			Denom:                 a.Denom,
+			Relays:                a.Relays,
+			HeartbeatRelays:       a.HeartbeatRelays,
+			DeviationRelays:       a.DeviationRelays,
+			MaxStaleness:          a.MaxStaleness.String(),
+			MaxUnrelayedDeviation: a.MaxUnrelayedDeviation,
+			FeeCost:               a.FeeCost,
+		}
+	}
+	return out
+}
+
+func printBacktestOutputs(outputs []backtestOutput) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SET\tDESTINATION\tINTERVAL\tDEVIATION\tDENOM\tRELAYS\tHEARTBEAT\tDEVIATED\tMAX STALENESS\tMAX UNRELAYED DEV\tFEE COST")
+	for _, out := range outputs {
+		for _, a := range out.Assets {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%d\t%d\t%d\t%s\t%.4f\t%.0f %s\n",
+				out.Name, out.Destination, out.Interval, out.Deviation,
+				a.Denom, a.Relays, a.HeartbeatRelays, a.DeviationRelays,
+				a.MaxStaleness, a.MaxUnrelayedDeviation, a.FeeCost, out.FeeDenom,
+			)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\t%d\t\t\t\t\t%.0f %s\n",
+			out.Name, out.Destination, out.Interval, out.Deviation,
+			"TOTAL", out.Relays, out.FeeCost, out.FeeDenom,
+		)
+	}
+	return w.Flush()
+}
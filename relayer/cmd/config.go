@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagOffline     = "offline"
+	flagNetwork     = "network"
+	flagOutput      = "output"
+	flagForce       = "force"
+	flagChainID     = "chain-id"
+	flagAxelarDenom = "axelar-denom"
+)
+
+func getConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Validate or generate relayer config files",
+	}
+
+	configCmd.AddCommand(getConfigValidateCmd())
+	configCmd.AddCommand(getConfigInitCmd())
+
+	return configCmd
+}
+
+func getConfigValidateCmd() *cobra.Command {
+	validateCmd := &cobra.Command{
+		Use:   "validate [config-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Validate a config file and print every invalid field with a hint",
+		Long: `Validate a config file, including the fragments of its config_dir. Besides missing
+values, it checks that coins, gas prices and durations are parseable, that the destination is a
+known axelar chain and that the contract is a checksummed address. Unless --offline is set, it
+also checks that every asset is in the accept list of the Ojo oracle module. Unknown destinations
+and unchecksummed addresses are only reported here, so that the relayer still runs on chains added
+to axelar since its release and with addresses in any case.`,
+		RunE:         configValidateCmdHandler,
+		SilenceUsage: true,
+	}
+
+	validateCmd.Flags().Bool(flagOffline, false, "skip the checks that query the Ojo node")
+
+	return validateCmd
+}
+
+func configValidateCmdHandler(cmd *cobra.Command, args []string) error {
+	offline, err := cmd.Flags().GetBool(flagOffline)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfigFromFlags(args[0], "")
+
+	var fieldErrs config.ValidationError
+	if err != nil && !errors.As(err, &fieldErrs) {
+		// the config could not be read
+		return err
+	}
+
+	fieldErrs = append(fieldErrs, cfg.ValidateDestination()...)
+	if !offline && cfg.RPC.GRPCEndpoint != "" {
+		fieldErrs = append(fieldErrs, validateAcceptList(cmd, cfg)...)
+	}
+
+	if len(fieldErrs) == 0 {
+		fmt.Printf("%s is valid\n", args[0])
+		return nil
+	}
+
+	for _, fe := range fieldErrs {
+		fmt.Printf("%s: %s\n", fe.Field, fe.Message)
+		if fe.Hint != "" {
+			fmt.Printf("  hint: %s\n", fe.Hint)
+		}
+	}
+	return fmt.Errorf("%s has %d invalid field(s)", args[0], len(fieldErrs))
+}
+
+// validateAcceptList checks that every configured asset is in the oracle accept list.
+func validateAcceptList(cmd *cobra.Command, cfg config.Config) config.ValidationError {
+	timeout, err := time.ParseDuration(cfg.RPC.RPCTimeout)
+	if err != nil || timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	acceptList, err := client.QueryAcceptList(cmd.Context(), cfg.RPC.GRPCEndpoint, timeout)
+	if err != nil {
+		return config.ValidationError{{
+			Field:   "rpc.grpc_endpoint",
+			Message: fmt.Sprintf("unable to query the oracle accept list: %s", err),
+			Hint:    "check that the Ojo node is reachable, or use --offline",
+		}}
+	}
+
+	accepted := map[string]bool{}
+	for _, denom := range acceptList {
+		accepted[denom] = true
+	}
+
+	var errs config.ValidationError
+	for i, a := range cfg.Assets {
+		if a.Denom == "" || accepted[a.Denom] {
+			continue
+		}
+		errs = append(errs, config.FieldError{
+			Field:   fmt.Sprintf("assets[%d].denom", i),
+			Message: fmt.Sprintf("%q is not in the oracle accept list", a.Denom),
+			Hint:    fmt.Sprintf("expected one of: %s", strings.Join(acceptList, ", ")),
+		})
+	}
+	return errs
+}
+
+func getConfigInitCmd() *cobra.Command {
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Args:  cobra.NoArgs,
+		Short: "Generate a commented config file for a network preset",
+		RunE:  configInitCmdHandler,
+	}
+
+	initCmd.Flags().String(flagNetwork, "testnet", "network preset (mainnet|testnet)")
+	initCmd.Flags().String(flagOutput, "", "file to write the config to; defaults to stdout")
+	initCmd.Flags().Bool(flagForce, false, "overwrite the output file if it exists")
+	initCmd.Flags().String(flagChainID, "", "chain ID of the Ojo network; required without a preset one")
+	initCmd.Flags().String(flagAxelarDenom, "", "IBC denom of AXL on the Ojo network; required without a preset one")
+
+	return initCmd
+}
+
+func configInitCmdHandler(cmd *cobra.Command, _ []string) error {
+	network, err := cmd.Flags().GetString(flagNetwork)
+	if err != nil {
+		return err
+	}
+	output, err := cmd.Flags().GetString(flagOutput)
+	if err != nil {
+		return err
+	}
+	force, err := cmd.Flags().GetBool(flagForce)
+	if err != nil {
+		return err
+	}
+
+	chainID, err := cmd.Flags().GetString(flagChainID)
+	if err != nil {
+		return err
+	}
+	axelarDenom, err := cmd.Flags().GetString(flagAxelarDenom)
+	if err != nil {
+		return err
+	}
+
+	bz, err := config.GenerateConfig(network, chainID, axelarDenom)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(bz)
+		return err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(output, flags, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s already exists; use --force to overwrite it", output)
+		}
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(bz); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s config to %s\n", network, output)
+	return nil
+}
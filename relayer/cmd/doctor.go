@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/doctor"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagMaxLatency = "max-latency"
+)
+
+func getDoctorCmd() *cobra.Command {
+	doctorCmd := &cobra.Command{
+		Use:   "doctor [config-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Check every dependency of the relayer and print a pass/fail report",
+		Long: `Run a sequence of preflight checks before going live: the keyring holds the key
+of the account, the node is on the configured chain, the node RPCs are reachable, the oracle
+has a price for every asset, the account exists and is funded, the axelarscan fee estimate
+works and, if relayer.evm_rpc is configured, the destination contract answers.`,
+		RunE:         doctorCmdHandler,
+		SilenceUsage: true,
+	}
+
+	doctorCmd.Flags().Duration(flagMaxLatency, time.Second, "highest acceptable latency of the Ojo node RPCs")
+	doctorCmd.Flags().String(flagFormat, "text", "Print the report in the given format (text|json)")
+
+	return doctorCmd
+}
+
+func doctorCmdHandler(cmd *cobra.Command, args []string) error {
+	maxLatency, err := cmd.Flags().GetDuration(flagMaxLatency)
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString(flagFormat)
+	if err != nil {
+		return err
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid output format: %s", format)
+	}
+
+	cfg, err := config.LoadConfigFromFlags(args[0], "")
+	if err != nil {
+		return err
+	}
+
+	keyringPass, err := getKeyringPassword()
+	if err != nil {
+		return err
+	}
+
+	checks := doctor.Checks(cfg, doctor.Options{
+		KeyringPass: keyringPass,
+		RPCTimeout:  defaultRPCTimeout,
+		MaxLatency:  maxLatency,
+	})
+	results := doctor.Run(cmd.Context(), checks)
+
+	if format == "json" {
+		bz, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bz))
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, r := range results {
+			fmt.Fprintf(w, "[%s]\t%s\t%s\n", strings.ToUpper(string(r.Status)), r.Name, r.Detail)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if !doctor.Passed(results) {
+		return fmt.Errorf("preflight checks failed")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagAssets      = "assets"
+	flagDestination = "destination"
+	flagContract    = "contract"
+	flagTimeout     = "timeout"
+	flagGMPTimeout  = "gmp-timeout"
+)
+
+type relayOnceOutput struct {
+	TxHash      string   `json:"tx_hash"`
+	Height      int64    `json:"height"`
+	GasUsed     int64    `json:"gas_used"`
+	FeePaid     string   `json:"fee_paid"`
+	Destination string   `json:"destination"`
+	Denoms      []string `json:"denoms"`
+	GMPStatus   string   `json:"gmp_status"`
+}
+
+func getRelayOnceCmd() *cobra.Command {
+	relayOnceCmd := &cobra.Command{
+		Use:   "relay-once [config-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Send a single relay tx and wait for its confirmation",
+		Long: `Send exactly one relay tx for the given assets, regardless of heartbeats and deviations,
+wait for it to be included in a block and print its hash, the fee paid and its GMP status.
+This is meant to push prices on demand from runbooks and scripts.`,
+		RunE: relayOnceCmdHandler,
+	}
+
+	relayOnceCmd.Flags().StringSlice(flagAssets, nil, "assets to relay; defaults to every asset in the config")
+	relayOnceCmd.Flags().String(flagDestination, "", "destination chain; defaults to the destination in the config")
+	relayOnceCmd.Flags().String(flagContract, "", "Ojo contract on the destination chain; required with --destination")
+	relayOnceCmd.MarkFlagsRequiredTogether(flagDestination, flagContract)
+	relayOnceCmd.Flags().Duration(flagTimeout, time.Minute, "how long to wait for the tx to be included in a block")
+	relayOnceCmd.Flags().Duration(flagGMPTimeout, 0,
+		"how long to wait for the GMP call to be executed; if zero, the GMP status is queried once")
+	relayOnceCmd.Flags().String(flagFormat, "text", "Print the result in the given format (text|json)")
+
+	return relayOnceCmd
+}
+
+func relayOnceCmdHandler(cmd *cobra.Command, args []string) error {
+	logger, err := getLogger(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfigFromFlags(args[0], "")
+	if err != nil {
+		return err
+	}
+
+	denoms, err := cmd.Flags().GetStringSlice(flagAssets)
+	if err != nil {
+		return err
+	}
+	if len(denoms) == 0 {
+		for _, a := range cfg.Assets {
+			denoms = append(denoms, a.Denom)
+		}
+	}
+	destination, err := cmd.Flags().GetString(flagDestination)
+	if err != nil {
+		return err
+	}
+	contract, err := cmd.Flags().GetString(flagContract)
+	if err != nil {
+		return err
+	}
+	if destination != "" {
+		// the Ojo contract of the config is on the configured destination
+		if err := config.ValidateChecksumAddress(contract); err != nil {
+			return fmt.Errorf("invalid --%s: %w", flagContract, err)
+		}
+		cfg.Relayer.Destination = destination
+		cfg.Relayer.Contract = contract
+	}
+	timeout, err := cmd.Flags().GetDuration(flagTimeout)
+	if err != nil {
+		return err
+	}
+	gmpTimeout, err := cmd.Flags().GetDuration(flagGMPTimeout)
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString(flagFormat)
+	if err != nil {
+		return err
+	}
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid output format: %s", format)
+	}
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	trapSignal(cancel, logger)
+
+	relayerClient, err := newRelayerClient(ctx, logger, cfg)
+	if err != nil {
+		return err
+	}
+
+	r, err := relayer.New(logger, relayerClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Relay(ctx, denoms)
+	if err != nil {
+		return err
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, timeout)
+	defer waitCancel()
+	resp, err := relayerClient.WaitForTx(waitCtx, result.TxResponse.TxHash)
+	if err != nil {
+		return err
+	}
+
+	gmpStatus, err := waitForGMPStatus(ctx, resp.TxHash, gmpTimeout)
+	if err != nil {
+		logger.Err(err).Str("tx_hash", resp.TxHash).Msg("unable to query gmp status")
+		gmpStatus = "unknown"
+	}
+
+	out := relayOnceOutput{
+		TxHash:      resp.TxHash,
+		Height:      resp.Height,
+		GasUsed:     resp.GasUsed,
+		FeePaid:     result.Fee.String(),
+		Destination: cfg.Relayer.Destination,
+		Denoms:      denoms,
+		GMPStatus:   gmpStatus,
+	}
+
+	if format == "json" {
+		bz, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(bz))
+		return err
+	}
+
+	_, err = fmt.Printf("tx_hash: %s\nheight: %d\ngas_used: %d\nfee_paid: %s\ndestination: %s\ndenoms: %v\ngmp_status: %s\n",
+		out.TxHash, out.Height, out.GasUsed, out.FeePaid, out.Destination, out.Denoms, out.GMPStatus)
+	return err
+}
+
+// waitForGMPStatus polls axelarscan until the GMP call of the given tx is
+// executed or errored, or the timeout elapses. The last known status is returned.
+func waitForGMPStatus(ctx context.Context, txHash string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := client.GMPStatus(ctx, txHash)
+		if err != nil {
+			return "", err
+		}
+		if status == "executed" || status == "error" || !time.Now().Before(deadline) {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, nil
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
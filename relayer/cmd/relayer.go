@@ -14,6 +14,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/input"
 	"github.com/ojo-network/ojo-evm/relayer/config"
 	"github.com/ojo-network/ojo-evm/relayer/relayer"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/admin"
 	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
 	"github.com/ojo-network/ojo/app/params"
 	"github.com/rs/zerolog"
@@ -29,6 +30,9 @@ const (
 	flagLogFormat = "log-format"
 
 	envVariablePass = "KEYRING_PASS"
+
+	// placeholder rpc timeout
+	defaultRPCTimeout = time.Second * 10
 )
 
 var rootCmd = &cobra.Command{
@@ -49,6 +53,10 @@ func init() {
 	rootCmd.PersistentFlags().String(flagLogLevel, zerolog.InfoLevel.String(), "logging level")
 	rootCmd.PersistentFlags().String(flagLogFormat, logLevelText, "logging format; must be either json or text")
 	rootCmd.AddCommand(getVersionCmd())
+	rootCmd.AddCommand(getBacktestCmd())
+	rootCmd.AddCommand(getRelayOnceCmd())
+	rootCmd.AddCommand(getConfigCmd())
+	rootCmd.AddCommand(getDoctorCmd())
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -61,21 +69,82 @@ func Execute() {
 }
 
 func relayerCmdHandler(cmd *cobra.Command, args []string) error {
-	logLvlStr, err := cmd.Flags().GetString(flagLogLevel)
+	logger, err := getLogger(cmd)
 	if err != nil {
 		return err
 	}
 
-	logLvl, err := zerolog.ParseLevel(logLvlStr)
+	cfg, err := config.LoadConfigFromFlags(args[0], "")
 	if err != nil {
 		return err
 	}
 
-	logFormatStr, err := cmd.Flags().GetString(flagLogFormat)
+	ctx, cancel := context.WithCancel(cmd.Context())
+	g, ctx := errgroup.WithContext(ctx)
+
+	// listen for and trap any OS signal to gracefully shutdown and exit
+	trapSignal(cancel, logger)
+
+	relayerClient, err := newRelayerClient(ctx, logger, cfg)
+	if err != nil {
+		return err
+	}
+
+	relayer, err := relayer.New(logger, relayerClient, cfg)
 	if err != nil {
 		return err
 	}
 
+	g.Go(func() error {
+		// start the process that observes and publishes exchange prices
+		return startRelayer(ctx, logger, relayer)
+	})
+
+	if cfg.Admin.ListenAddr != "" {
+		g.Go(func() error {
+			// serve the status and the halts of the circuit breaker
+			return admin.NewServer(logger, cfg.Admin.ListenAddr, cfg.Admin.Token, relayer).Start(ctx)
+		})
+	}
+
+	g.Go(func() error {
+		// reload the config on SIGHUP or when a config file changes
+		err := config.Watch(ctx, args[0], "", trapReloadSignal(ctx), func(cfg config.Config, err error) {
+			if err != nil {
+				logger.Err(err).Msg("invalid config; keeping the current one")
+				return
+			}
+			relayer.Reload(cfg)
+		})
+		if err != nil {
+			// keep relaying, config changes then require a restart
+			logger.Err(err).Msg("unable to watch the config")
+		}
+		return nil
+	})
+
+	// Block main process until all spawned goroutines have gracefully exited and
+	// signal has been captured in the main process or if an error occurs.
+	return g.Wait()
+}
+
+// getLogger creates a logger from the log level and format flags.
+func getLogger(cmd *cobra.Command) (zerolog.Logger, error) {
+	logLvlStr, err := cmd.Flags().GetString(flagLogLevel)
+	if err != nil {
+		return zerolog.Logger{}, err
+	}
+
+	logLvl, err := zerolog.ParseLevel(logLvlStr)
+	if err != nil {
+		return zerolog.Logger{}, err
+	}
+
+	logFormatStr, err := cmd.Flags().GetString(flagLogFormat)
+	if err != nil {
+		return zerolog.Logger{}, err
+	}
+
 	var logWriter io.Writer
 	switch strings.ToLower(logFormatStr) {
 	case logLevelJSON:
@@ -85,31 +154,21 @@ func relayerCmdHandler(cmd *cobra.Command, args []string) error {
 		logWriter = zerolog.ConsoleWriter{Out: os.Stderr}
 
 	default:
-		return fmt.Errorf("invalid logging format: %s", logFormatStr)
-	}
-
-	cfg, err := config.LoadConfigFromFlags(args[0], "")
-	if err != nil {
-		return err
+		return zerolog.Logger{}, fmt.Errorf("invalid logging format: %s", logFormatStr)
 	}
 
-	ctx, cancel := context.WithCancel(cmd.Context())
-	g, ctx := errgroup.WithContext(ctx)
-
-	logger := zerolog.New(logWriter).Level(logLvl).With().Timestamp().Logger()
-
-	// listen for and trap any OS signal to gracefully shutdown and exit
-	trapSignal(cancel, logger)
+	return zerolog.New(logWriter).Level(logLvl).With().Timestamp().Logger(), nil
+}
 
+// newRelayerClient gathers the keyring password and creates a relayer client
+// from the given config.
+func newRelayerClient(ctx context.Context, logger zerolog.Logger, cfg config.Config) (client.RelayerClient, error) {
 	// Gather pass via env variable || std input
 	keyringPass, err := getKeyringPassword()
 	if err != nil {
-		return err
+		return client.RelayerClient{}, err
 	}
 
-	// placeholder rpc timeout
-	rpcTimeout := time.Second * 10
-
 	relayerClient, err := client.NewRelayerClient(
 		ctx,
 		logger,
@@ -118,29 +177,20 @@ func relayerCmdHandler(cmd *cobra.Command, args []string) error {
 		cfg.Keyring.Dir,
 		keyringPass,
 		cfg.RPC.TMRPCEndpoint,
-		rpcTimeout,
+		defaultRPCTimeout,
 		cfg.Account.Address,
 		cfg.RPC.GRPCEndpoint,
 		cfg.Gas,
 		cfg.GasPrices,
 	)
 	if err != nil {
-		return err
-	}
-
-	relayer, err := relayer.New(logger, relayerClient, cfg)
-	if err != nil {
-		return err
+		return client.RelayerClient{}, err
 	}
-
-	g.Go(func() error {
-		// start the process that observes and publishes exchange prices
-		return startRelayer(ctx, logger, relayer)
-	})
-
-	// Block main process until all spawned goroutines have gracefully exited and
-	// signal has been captured in the main process or if an error occurs.
-	return g.Wait()
+	relayerClient.Retry = cfg.Retry
+	relayerClient.MaxGasPrices = cfg.MaxGasPrices
+	relayerClient.GasPriceBump = cfg.GasPriceBump
+	relayerClient.GasStrategy = cfg.GasStrategy
+	return relayerClient, nil
 }
 
 // trapSignal will listen for any OS signal and invoke Done on the main
@@ -155,9 +205,40 @@ func trapSignal(cancel context.CancelFunc, logger zerolog.Logger) {
 		sig := <-sigCh
 		logger.Info().Str("signal", sig.String()).Msg("caught signal; shutting down...")
 		cancel()
+
+		// a second signal exits without waiting for in-flight broadcasts
+		sig = <-sigCh
+		logger.Warn().Str("signal", sig.String()).Msg("caught second signal; exiting now")
+		os.Exit(1)
 	}()
 }
 
+// trapReloadSignal returns a channel receiving a value on every SIGHUP until
+// the context is done.
+func trapReloadSignal(ctx context.Context) <-chan struct{} {
+	sigCh := make(chan os.Signal, 1)
+	reloadCh := make(chan struct{}, 1)
+
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				select {
+				case reloadCh <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return reloadCh
+}
+
 func getKeyringPassword() (string, error) {
 	reader := bufio.NewReader(os.Stdin)
 
@@ -176,16 +257,16 @@ func startRelayer(ctx context.Context, logger zerolog.Logger, r *relayer.Relayer
 		srvErrCh <- r.Start(ctx)
 	}()
 
-	for {
-		select {
-		case <-ctx.Done():
-			logger.Info().Msg("shutting down relayer..")
-			return nil
+	select {
+	case <-ctx.Done():
+		logger.Info().Msg("shutting down relayer..")
+		// wait for in-flight broadcasts to drain
+		return <-srvErrCh
 
-		case err := <-srvErrCh:
+	case err := <-srvErrCh:
+		if err != nil {
 			logger.Err(err).Msg("error starting the relayer relayer")
-			r.Stop()
-			return err
 		}
+		return err
 	}
 }
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"encoding/hex"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -9,11 +11,29 @@ import (
 
 const (
 	SampleNodeConfigPath = "relayer.toml"
+
+	defaultElectionCheckInterval = 30 * time.Second
+	defaultDeliveryLatency       = 10 * time.Minute
+	defaultGasPriceBump          = 1.5
+	defaultGasAdjustment         = 1.3
+	defaultGasPriceMultiplier    = 1.0
+
+	GasModeFixed    = "fixed"
+	GasModeSimulate = "simulate"
+	GasModeNode     = "node"
 )
 
 var (
 	validate = validator.New()
 
+	// default retries of the remote calls, by operation
+	defaultRetries = Retries{
+		PriceQuery:   Retry{Base: 100 * time.Millisecond, Max: time.Second, Jitter: 0.2, MaxAttempts: 3},
+		FeeEstimate:  Retry{Base: 500 * time.Millisecond, Max: 5 * time.Second, Jitter: 0.2, MaxAttempts: 3},
+		Broadcast:    Retry{Base: time.Second, Max: 10 * time.Second, Jitter: 0.2, MaxAttempts: 5},
+		Confirmation: Retry{Base: time.Second, Max: 5 * time.Second, Jitter: 0.2, MaxAttempts: 60},
+	}
+
 	// ErrEmptyConfigPath defines a sentinel error for an empty config path.
 	ErrEmptyConfigPath = errors.New("empty configuration file path")
 )
@@ -29,7 +49,60 @@ type (
 		GasPrices string    `mapstructure:"gas_prices"`
 		Relayer   Relayer   `mapstructure:"relayer" validate:"required,gt=0,dive,required"`
 		Assets    []Assets  `mapstructure:"assets" validate:"required,gt=0,dive,required"`
+		Pairs     []Pair    `mapstructure:"pairs"`
 		AxelarGas AxelarGas `mapstructure:"axelar_gas" validate:"required,gt=0,dive,required"`
+		Election  Election  `mapstructure:"election"`
+
+		// When a relay tx is rejected for an insufficient fee, its gas
+		// prices are multiplied by GasPriceBump, up to MaxGasPrices. Without
+		// MaxGasPrices, the gas prices are never bumped, and GasPriceBump and
+		// the ladder of the gas strategy must not be set.
+		MaxGasPrices string      `mapstructure:"max_gas_prices"`
+		GasPriceBump float64     `mapstructure:"gas_price_bump"`
+		GasStrategy  GasStrategy `mapstructure:"gas_strategy"`
+
+		Quorum         Quorum         `mapstructure:"quorum"`
+		LightClient    LightClient    `mapstructure:"light_client"`
+		CircuitBreaker CircuitBreaker `mapstructure:"circuit_breaker"`
+		Admin          Admin          `mapstructure:"admin"`
+		Alerts         Alerts         `mapstructure:"alerts"`
+		Retry          Retries        `mapstructure:"retry"`
+	}
+
+	// GasStrategy defines how the gas and gas prices of the relay txs are
+	// set. Mode is "fixed", the default, for gas and gas_prices; "simulate"
+	// to simulate the gas of every tx and multiply it by GasAdjustment; or
+	// "node" for the minimum gas prices of the Ojo node times Multiplier, up
+	// to max_gas_prices, falling back to gas_prices. Ladder optionally holds
+	// the multipliers of the first gas prices of a tx on its successive
+	// insufficient fee rejections, replacing gas_price_bump.
+	GasStrategy struct {
+		Mode          string    `mapstructure:"mode"`
+		GasAdjustment float64   `mapstructure:"gas_adjustment"`
+		Multiplier    float64   `mapstructure:"multiplier"`
+		Ladder        []float64 `mapstructure:"ladder"`
+	}
+
+	// Retries defines the retries of the remote calls of the relayer, by
+	// operation: price queries to the Ojo node, gas fee estimates on
+	// axelarscan, tx broadcasts and the confirmations of relay-once.
+	Retries struct {
+		PriceQuery   Retry `mapstructure:"price_query"`
+		FeeEstimate  Retry `mapstructure:"fee_estimate"`
+		Broadcast    Retry `mapstructure:"broadcast"`
+		Confirmation Retry `mapstructure:"confirmation"`
+	}
+
+	// Retry defines the retries of a failed remote call. The wait before
+	// each retry doubles from Base up to Max, randomized by up to Jitter of
+	// itself, e.g. 0.2 for 20%. The call is made at most MaxAttempts times,
+	// including the first one. Unset fields take the defaults of the
+	// operation.
+	Retry struct {
+		Base        time.Duration `mapstructure:"base"`
+		Max         time.Duration `mapstructure:"max"`
+		Jitter      float64       `mapstructure:"jitter"`
+		MaxAttempts int           `mapstructure:"max_attempts"`
 	}
 
 	// Account defines account related configuration that is related to the Ojo
@@ -57,6 +130,36 @@ type (
 		Deviation   float64       `mapstructure:"deviation" validate:"required"`
 		Destination string        `mapstructure:"destination" validate:"required"`
 		Contract    string        `mapstructure:"contract" validate:"required"`
+		// EVMRPC is an optional JSON-RPC endpoint of the destination chain,
+		// used to read the state of the Ojo contract.
+		EVMRPC string `mapstructure:"evm_rpc"`
+		// Schedule is an optional cron expression, in UTC, of the heartbeats
+		// to the destination, replacing Interval in the relay policies.
+		// Jitter delays each heartbeat by up to its value.
+		Schedule string        `mapstructure:"schedule"`
+		Jitter   time.Duration `mapstructure:"jitter"`
+		// When a relay goes out, assets which deviated by at least
+		// Piggyback times their threshold, or whose heartbeat is due within
+		// PiggybackWindow, join it, up to MaxBatch assets per relay.
+		Piggyback       float64       `mapstructure:"piggyback"`
+		PiggybackWindow time.Duration `mapstructure:"piggyback_window"`
+		MaxBatch        int           `mapstructure:"max_batch"`
+		// Once an asset triggers, the relay waits up to BatchWindow, or
+		// BatchWindowBlocks, for other triggers, unless an asset deviated by
+		// at least UrgentDeviation.
+		BatchWindow       time.Duration `mapstructure:"batch_window"`
+		BatchWindowBlocks int64         `mapstructure:"batch_window_blocks"`
+		UrgentDeviation   float64       `mapstructure:"urgent_deviation"`
+		// Callback is the optional contract called with every relay to the
+		// destination, unless the relayed assets have their own.
+		Callback Callback `mapstructure:"callback"`
+		// Relays must reach the destination within the resolve window of
+		// the contract, read through EVMRPC, and heartbeats within
+		// MaxStaleness, the optional staleness limit of its consumers.
+		// DeliveryLatency is the expected time from a relay to its delivery
+		// on the destination.
+		MaxStaleness    time.Duration `mapstructure:"max_staleness"`
+		DeliveryLatency time.Duration `mapstructure:"delivery_latency"`
 	}
 
 	AxelarGas struct {
@@ -65,16 +168,281 @@ type (
 		Default    string `mapstructure:"default" validate:"required"`
 	}
 
+	// Election defines the optional leader election between relayer replicas.
+	// Only the leader relays prices.
+	Election struct {
+		// Mode is either "file", to elect the replica holding a lock on
+		// LockFile, or "observe", to take over when LeaderAddress has not
+		// relayed within the heartbeat interval plus Grace.
+		Mode          string        `mapstructure:"mode"`
+		LockFile      string        `mapstructure:"lock_file"`
+		LeaderAddress string        `mapstructure:"leader_address"`
+		Grace         time.Duration `mapstructure:"grace"`
+		CheckInterval time.Duration `mapstructure:"check_interval"`
+	}
+
+	// Quorum defines the optional quorum reads of the prices. When set, prices
+	// are read from every endpoint at the same height, and MinAgree of them
+	// must agree within Tolerance.
+	Quorum struct {
+		GRPCEndpoints []string `mapstructure:"grpc_endpoints"`
+		MinAgree      int      `mapstructure:"min_agree"`
+		// Tolerance is the largest relative difference between agreeing
+		// prices, e.g. 0.001 for 0.1%.
+		Tolerance float64 `mapstructure:"tolerance"`
+	}
+
+	// LightClient defines the optional verification of the oracle prices with
+	// merkle proofs against the app hashes of a CometBFT light client, which
+	// is enabled when TrustedHeight is set. The light client starts from the
+	// trusted header and cross-checks the primary rpc.tmrpc_endpoint with
+	// at least one witness.
+	LightClient struct {
+		TrustedHeight  int64         `mapstructure:"trusted_height"`
+		TrustedHash    string        `mapstructure:"trusted_hash"`
+		TrustingPeriod time.Duration `mapstructure:"trusting_period"`
+		Witnesses      []string      `mapstructure:"witnesses"`
+	}
+
+	// CircuitBreaker defines the staleness check of the oracle prices. A denom
+	// failing the checks of the circuit breaker is halted until it is cleared
+	// through the admin API.
+	CircuitBreaker struct {
+		// MaxStalePeriods is the number of median stamp periods after which a
+		// denom without a new median is stale. Zero disables the check.
+		MaxStalePeriods uint64 `mapstructure:"max_stale_periods"`
+	}
+
+	// Admin defines the optional admin HTTP API.
+	Admin struct {
+		ListenAddr string `mapstructure:"listen_addr"`
+		// Token is an optional bearer token required by every admin request.
+		Token string `mapstructure:"token"`
+	}
+
+	// Alerts defines where alerts are sent besides the logs and metrics.
+	Alerts struct {
+		// Webhook is an optional URL that alerts are POSTed to as JSON.
+		Webhook string `mapstructure:"webhook"`
+	}
+
 	Assets struct {
 		Denom string `mapstructure:"denom" validate:"required"`
+		// MinPrice and MaxPrice are optional sanity bounds of the price.
+		MinPrice float64 `mapstructure:"min_price"`
+		MaxPrice float64 `mapstructure:"max_price"`
+		// MaxStep is the optional largest change between two consecutive
+		// prices, e.g. 0.2 for 20%.
+		MaxStep float64 `mapstructure:"max_step"`
+		// Reference is an optional independent source the price is checked
+		// against before relaying.
+		Reference Reference `mapstructure:"reference"`
+		// Policy decides when the asset is relayed. It defaults to a
+		// heartbeat or a deviation relay, as configured in [relayer].
+		Policy Policy `mapstructure:"policy"`
+		// Smoothing optionally smooths the prices the policy decides on.
+		Smoothing Smoothing `mapstructure:"smoothing"`
+		// Callback is the optional contract called when the asset is
+		// relayed, replacing the callback of the relayer section.
+		Callback Callback `mapstructure:"callback"`
+		// Composed is an optional feed of the destination chain composing
+		// the price of the asset with an on-chain rate. The relay policy
+		// then decides on the composed value.
+		Composed Composed `mapstructure:"composed"`
+	}
+
+	// Composed defines a price feed whose value consumers multiply by the
+	// price of the asset, read from the destination chain through
+	// relayer.evm_rpc. Type is "mellow" for the getRate() of the vault oracle
+	// at Contract, "yn" for the getRate() of the viewer at Contract, or
+	// "inception" for the getTotalDeposited() of the vault at Contract per
+	// total supply of the Token, as the feeds compute them. Decimals is the
+	// number of decimals of the rate, 18 by default, and the rate is read
+	// again every Refresh, one minute by default.
+	Composed struct {
+		Type     string        `mapstructure:"type"`
+		Contract string        `mapstructure:"contract"`
+		Token    string        `mapstructure:"token"`
+		Decimals int           `mapstructure:"decimals"`
+		Refresh  time.Duration `mapstructure:"refresh"`
+	}
+
+	// Pair defines a quoted pair feed, whose base/quote ratio is watched on
+	// top of the prices of its legs. Both legs are relayed together when the
+	// ratio deviated by Deviation, or when either leg was not relayed within
+	// Heartbeat; they default to relayer.deviation and relayer.interval.
+	Pair struct {
+		Base      string        `mapstructure:"base"`
+		Quote     string        `mapstructure:"quote"`
+		Deviation float64       `mapstructure:"deviation"`
+		Heartbeat time.Duration `mapstructure:"heartbeat"`
+	}
+
+	// Callback defines a contract the Ojo contract calls after posting the
+	// relayed prices, e.g. to trigger a rebalance. Function is the signature
+	// of the called function, whose selector is sent with the relay. It is
+	// called with the names of the relayed assets and Params, so it must take
+	// (bytes32[],bytes), e.g. "rebalance(bytes32[],bytes)". Params are hex
+	// encoded bytes, usually ABI encoded static arguments.
+	Callback struct {
+		Contract string `mapstructure:"contract"`
+		Function string `mapstructure:"function"`
+		Params   string `mapstructure:"params"`
+	}
+
+	// Smoothing defines the smoothing of the prices of an asset. Type is "ema"
+	// for an exponential moving average with a time constant of Window, or
+	// "twap" for a time-weighted average over Window kept in a ring buffer of
+	// Samples prices. Confirmations is the number of consecutive ticks the
+	// policy must decide to relay before the asset is relayed.
+	Smoothing struct {
+		Type          string        `mapstructure:"type"`
+		Window        time.Duration `mapstructure:"window"`
+		Samples       int           `mapstructure:"samples"`
+		Confirmations int           `mapstructure:"confirmations"`
+	}
+
+	// Policy defines the relay policy of an asset. Type is "heartbeat",
+	// "deviation", "adaptive", a registered custom type, or "and" / "or" to
+	// compose the nested Policies.
+	Policy struct {
+		Type string `mapstructure:"type"`
+		// Interval of a heartbeat policy; defaults to relayer.interval.
+		Interval time.Duration `mapstructure:"interval"`
+		// Schedule is the cron expression of a heartbeat policy, in UTC,
+		// replacing its interval; defaults to relayer.schedule if Interval
+		// is unset. Jitter defaults to relayer.jitter.
+		Schedule string        `mapstructure:"schedule"`
+		Jitter   time.Duration `mapstructure:"jitter"`
+		// Threshold of a deviation policy; defaults to relayer.deviation.
+		Threshold float64  `mapstructure:"threshold"`
+		Policies  []Policy `mapstructure:"policies"`
+
+		// An adaptive policy scales its deviation threshold with the realized
+		// volatility of the prices over Window, between MinThreshold and
+		// MaxThreshold. Once the price of a stable asset moves more than
+		// DepegBand away from its Peg, the threshold is StressThreshold.
+		MinThreshold         float64       `mapstructure:"min_threshold"`
+		MaxThreshold         float64       `mapstructure:"max_threshold"`
+		Window               time.Duration `mapstructure:"window"`
+		VolatilityMultiplier float64       `mapstructure:"volatility_multiplier"`
+		Peg                  float64       `mapstructure:"peg"`
+		DepegBand            float64       `mapstructure:"depeg_band"`
+		StressThreshold      float64       `mapstructure:"stress_threshold"`
+		// Params holds the settings of custom policy types.
+		Params map[string]interface{} `mapstructure:"params"`
+	}
+
+	// Reference defines an independent source of reference prices. Type is
+	// "ojo" to query a second Ojo node at GRPCEndpoint, or "http" to read the
+	// number at JSONPath in the response of URL, where "{denom}" is replaced
+	// by the denom of the asset.
+	Reference struct {
+		Type         string        `mapstructure:"type"`
+		GRPCEndpoint string        `mapstructure:"grpc_endpoint"`
+		URL          string        `mapstructure:"url"`
+		JSONPath     string        `mapstructure:"json_path"`
+		Timeout      time.Duration `mapstructure:"timeout"`
+		// Tolerance is the largest relative difference between the Ojo and
+		// the reference prices, e.g. 0.02 for 2%.
+		Tolerance float64 `mapstructure:"tolerance"`
 	}
 )
 
-// Validate returns an error if the Config object is invalid.
+// Validate returns an error if the Config object is invalid. Invalid fields
+// are reported as a ValidationError.
 func (c Config) Validate() (err error) {
-	return validate.Struct(c)
+	var errs ValidationError
+	if err := validate.Struct(c); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			return err
+		}
+		for _, fe := range fieldErrs {
+			errs = append(errs, newFieldError(fe))
+		}
+	}
+
+	errs = append(errs, c.validateSemantics()...)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Enabled returns true if a contract is called back.
+func (c Callback) Enabled() bool {
+	return c.Contract != ""
+}
+
+// Signature returns the function signature without whitespace, as hashed
+// into its selector.
+func (c Callback) Signature() string {
+	return strings.Join(strings.Fields(c.Function), "")
+}
+
+// ParamsBytes returns the decoded params, with or without 0x prefix.
+func (c Callback) ParamsBytes() ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(c.Params, "0x"))
+}
+
+// Enabled returns true if the prices are verified with a light client.
+func (lc LightClient) Enabled() bool {
+	return lc.TrustedHeight > 0
+}
+
+// HasAsset returns true if the given denom is in the assets of the config.
+func (c Config) HasAsset(denom string) bool {
+	for _, a := range c.Assets {
+		if a.Denom == denom {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the name of the pair, e.g. "WETH/ETH".
+func (p Pair) Name() string {
+	return p.Base + "/" + p.Quote
 }
 
-// noop
 func (c *Config) setDefaults() {
+	if c.Election.CheckInterval == 0 {
+		c.Election.CheckInterval = defaultElectionCheckInterval
+	}
+	if c.Relayer.DeliveryLatency == 0 {
+		c.Relayer.DeliveryLatency = defaultDeliveryLatency
+	}
+	// the gas prices are only bumped up to the max gas prices
+	if c.GasPriceBump == 0 && c.MaxGasPrices != "" {
+		c.GasPriceBump = defaultGasPriceBump
+	}
+	if c.GasStrategy.Mode == "" {
+		c.GasStrategy.Mode = GasModeFixed
+	}
+	if c.GasStrategy.Mode == GasModeSimulate && c.GasStrategy.GasAdjustment == 0 {
+		c.GasStrategy.GasAdjustment = defaultGasAdjustment
+	}
+	if c.GasStrategy.Mode == GasModeNode && c.GasStrategy.Multiplier == 0 {
+		c.GasStrategy.Multiplier = defaultGasPriceMultiplier
+	}
+	c.Retry.PriceQuery.setDefaults(defaultRetries.PriceQuery)
+	c.Retry.FeeEstimate.setDefaults(defaultRetries.FeeEstimate)
+	c.Retry.Broadcast.setDefaults(defaultRetries.Broadcast)
+	c.Retry.Confirmation.setDefaults(defaultRetries.Confirmation)
+}
+
+func (r *Retry) setDefaults(defaults Retry) {
+	if r.Base == 0 {
+		r.Base = defaults.Base
+	}
+	if r.Max == 0 {
+		r.Max = defaults.Max
+	}
+	if r.Jitter == 0 {
+		r.Jitter = defaults.Jitter
+	}
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = defaults.MaxAttempts
+	}
 }
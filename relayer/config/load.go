@@ -47,12 +47,13 @@ func filesInFolder(folder string) ([]string, error) {
 // parseConfigDir attempts to read the config_dir from the node config file.
 func parseConfigDir(nodeConfigPath string) (string, error) {
 	var cfg Config
-	viper.SetConfigFile(nodeConfigPath)
-	if err := viper.ReadInConfig(); err != nil {
+	v := viper.New()
+	v.SetConfigFile(nodeConfigPath)
+	if err := v.ReadInConfig(); err != nil {
 		return "", fmt.Errorf("failed to read node config: %w", err)
 	}
-	if err := viper.Unmarshal(&cfg); err != nil {
-		return "", fmt.Errorf("failed to decode node config: %w", err)
+	if err := v.Unmarshal(&cfg); err != nil {
+		return "", fmt.Errorf("failed to decode node config: %w", decodeError(err))
 	}
 	return cfg.ConfigDir, nil
 }
@@ -68,23 +69,25 @@ func ParseConfig(configPath string) (Config, error) {
 func ParseConfigs(configPaths []string) (Config, error) {
 	var cfg Config
 
-	viper.AutomaticEnv()
+	// use a fresh instance so that reloads don't keep the values of removed keys
+	v := viper.New()
+	v.AutomaticEnv()
 	// Allow nested env vars to be read with underscore separators.
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
 	// Loop over each config path and merge its values into the previous one
 	for _, configPath := range configPaths {
 		if configPath == "" {
 			return cfg, ErrEmptyConfigPath
 		}
-		viper.SetConfigFile(configPath)
-		if err := viper.MergeInConfig(); err != nil {
+		v.SetConfigFile(configPath)
+		if err := v.MergeInConfig(); err != nil {
 			return cfg, fmt.Errorf("failed to read config: %w", err)
 		}
 	}
 
-	if err := viper.Unmarshal(&cfg); err != nil {
-		return cfg, fmt.Errorf("failed to decode config: %w", err)
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, fmt.Errorf("failed to decode config: %w", decodeError(err))
 	}
 
 	cfg.setDefaults()
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Network defines the presets of an Ojo network used to generate a config.
+type Network struct {
+	Name               string
+	ChainID            string
+	DefaultDestination string
+	Contract           string
+	AxelarDenom        string
+	// Destinations are the axelar chain names supported on this network.
+	// Ref: https://docs.axelar.dev/resources/contract-addresses/mainnet
+	Destinations []string
+}
+
+// Networks are the supported config presets, keyed by name.
+var Networks = map[string]Network{
+	"mainnet": {
+		Name:               "mainnet",
+		ChainID:            "",
+		DefaultDestination: "arbitrum",
+		Contract:           "0x5BB3E85f91D08fe92a3D123EE35050b763D6E6A7",
+		AxelarDenom:        "",
+		Destinations: []string{
+			"Ethereum", "arbitrum", "optimism", "base", "Polygon", "Avalanche", "binance",
+			"Fantom", "Moonbeam", "celo", "kava", "filecoin", "linea", "mantle", "scroll",
+			"blast", "fraxtal",
+		},
+	},
+	"testnet": {
+		Name:               "testnet",
+		ChainID:            "agamotto",
+		DefaultDestination: "arbitrum-sepolia",
+		Contract:           "0x5BB3E85f91D08fe92a3D123EE35050b763D6E6A7",
+		AxelarDenom:        "ibc/0E1517E2771CA7C03F2ED3F9BAECCAEADF0BFD79B89679E834933BC0F179AD98",
+		Destinations: []string{
+			"ethereum-sepolia", "arbitrum-sepolia", "optimism-sepolia", "base-sepolia",
+			"polygon-sepolia", "Avalanche", "binance", "Fantom", "Moonbeam", "celo", "kava",
+			"filecoin-2", "linea-sepolia", "mantle-sepolia", "scroll", "blast-sepolia", "fraxtal",
+		},
+	},
+}
+
+// KnownDestinations returns the sorted axelar chain names of every network.
+func KnownDestinations() []string {
+	set := map[string]bool{}
+	for _, n := range Networks {
+		for _, d := range n.Destinations {
+			set[d] = true
+		}
+	}
+
+	destinations := make([]string, 0, len(set))
+	for d := range set {
+		destinations = append(destinations, d)
+	}
+	sort.Strings(destinations)
+	return destinations
+}
+
+// IsKnownDestination returns true if the destination is a known axelar chain
+// name. Axelar chain names are case insensitive.
+func IsKnownDestination(destination string) bool {
+	for _, n := range Networks {
+		for _, d := range n.Destinations {
+			if strings.EqualFold(d, destination) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var configTemplate = template.Must(template.New("config").Parse(`# Ojo relayer config generated for {{ .Name }}.
+# Run "relayer config validate" after editing it.
+
+# Gas limit and gas prices of the relay txs on the Ojo chain.
+gas = 1000000
+gas_prices = "0.025uojo"
+
+# Optional directory of additional config files merged into this one.
+# config_dir = "relayer.d"
+
+[account]
+# Ojo address signing the relay txs. It must be in the keyring below.
+address = "ojo1..."
+# Chain ID of the Ojo network.
+chain_id = "{{ .ChainID }}"
+
+[keyring]
+# Keyring backend, one of os, file or test. Do not use test on mainnet.
+backend = "os"
+dir = "/home/ojo/.ojo"
+
+[rpc]
+grpc_endpoint = "localhost:9090"
+rpc_timeout = "10s"
+tmrpc_endpoint = "http://localhost:26657"
+
+[relayer]
+# "heartbeat" interval for the relayer
+interval = "24h"
+# deviation expressed as a percentage
+# e.g., 0.01 means 1%
+deviation = "0.05"
+# Axelar name of the destination chain, one of:
+# {{ range $i, $d := .Destinations }}{{ if $i }}, {{ end }}{{ $d }}{{ end }}
+destination = "{{ .DefaultDestination }}"
+# Ojo contract on the destination chain, as an EIP-55 checksummed address.
+contract = "{{ .Contract }}"
+
+# These are the assets we want to periodically push.
+# Denoms must be in the accept list of the Ojo oracle module.
+[[assets]]
+denom = "BTC"
+[[assets]]
+denom = "ETH"
+
+# This struct is used to estimate the gas prices to pay axelar
+[axelar_gas]
+# IBC denom of AXL on the Ojo chain.
+denom = "{{ .AxelarDenom }}"
+# Multiplier applied to the axelarscan fee estimate.
+multiplier = "1.2"
+# Fee paid when the axelarscan fee estimate is unavailable.
+default = "1000000"
+`))
+
+// GenerateConfig returns a commented config file for the given network
+// preset. The chain ID and AXL denom, if set, replace the ones of the preset,
+// and are required when the preset has none.
+func GenerateConfig(network, chainID, axelarDenom string) ([]byte, error) {
+	n, ok := Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q; expected mainnet or testnet", network)
+	}
+	if chainID != "" {
+		n.ChainID = chainID
+	}
+	if axelarDenom != "" {
+		n.AxelarDenom = axelarDenom
+	}
+	if n.ChainID == "" {
+		return nil, fmt.Errorf("%s has no preset chain ID; set --chain-id to the chain ID reported by your node", network)
+	}
+	if n.AxelarDenom == "" {
+		return nil, fmt.Errorf("%s has no preset AXL denom; set --axelar-denom to the IBC denom of AXL on your network", network)
+	}
+
+	var buf bytes.Buffer
+	if err := configTemplate.Execute(&buf, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,650 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/cron"
+	"golang.org/x/crypto/sha3"
+)
+
+type (
+	// FieldError defines a validation error of a single config field, identified
+	// by its path in the config file, e.g. "relayer.interval".
+	FieldError struct {
+		Field   string
+		Message string
+		Hint    string
+	}
+
+	// ValidationError defines the set of field errors of an invalid config.
+	ValidationError []FieldError
+)
+
+func init() {
+	// report field paths as they are written in the config file
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+}
+
+func (e FieldError) Error() string {
+	if e.Hint == "" {
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+	return fmt.Sprintf("%s: %s (hint: %s)", e.Field, e.Message, e.Hint)
+}
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return fmt.Sprintf("invalid config: %s", strings.Join(msgs, "; "))
+}
+
+// newFieldError converts a validator error into a FieldError.
+func newFieldError(fe validator.FieldError) FieldError {
+	// strip the root struct name from the namespace
+	_, field, _ := strings.Cut(fe.Namespace(), ".")
+
+	switch fe.Tag() {
+	case "required":
+		return FieldError{Field: field, Message: "missing value", Hint: "set this field in the config file"}
+	case "gt":
+		return FieldError{Field: field, Message: "must not be empty"}
+	default:
+		return FieldError{Field: field, Message: fmt.Sprintf("failed the %q check", fe.Tag())}
+	}
+}
+
+// decodeError converts the errors of decoding a config file into field errors,
+// e.g. an unparseable duration. Other errors are returned as is.
+func decodeError(err error) error {
+	var decodeErr *mapstructure.Error
+	if !errors.As(err, &decodeErr) {
+		return err
+	}
+
+	errs := make(ValidationError, len(decodeErr.Errors))
+	for i, msg := range decodeErr.Errors {
+		fe := FieldError{Message: msg}
+		// messages are formatted as "error decoding 'relayer.interval': cause"
+		if rest, ok := strings.CutPrefix(msg, "error decoding '"); ok {
+			if field, cause, ok := strings.Cut(rest, "': "); ok {
+				fe.Field, fe.Message = field, cause
+			}
+		}
+		if strings.Contains(fe.Message, "duration") {
+			fe.Hint = `expected a duration such as "24h" or "500ms"`
+		}
+		errs[i] = fe
+	}
+	return errs
+}
+
+// validateSemantics checks that the config values are parseable and coherent.
+// Missing values are reported by the struct validation.
+func (c Config) validateSemantics() ValidationError {
+	var errs ValidationError
+	add := func(field, msg, hint string) {
+		errs = append(errs, FieldError{Field: field, Message: msg, Hint: hint})
+	}
+
+	if c.GasPrices != "" {
+		if _, err := sdk.ParseDecCoins(c.GasPrices); err != nil {
+			add("gas_prices", err.Error(), "expected an amount followed by a denom, e.g. 0.025uojo")
+		}
+	}
+	if c.MaxGasPrices != "" {
+		maxPrices, err := sdk.ParseDecCoins(c.MaxGasPrices)
+		if err != nil {
+			add("max_gas_prices", err.Error(), "expected an amount followed by a denom, e.g. 0.1uojo")
+		} else if prices, err := sdk.ParseDecCoins(c.GasPrices); err == nil {
+			for _, p := range prices {
+				if maxPrices.AmountOf(p.Denom).LT(p.Amount) {
+					add("max_gas_prices", fmt.Sprintf("below gas_prices %s", c.GasPrices),
+						"expected a cap at or above gas_prices in the same denoms")
+					break
+				}
+			}
+		}
+	}
+	if c.GasPriceBump != 0 && c.GasPriceBump <= 1 {
+		add("gas_price_bump", fmt.Sprintf("%v is out of range", c.GasPriceBump),
+			"expected a factor above 1, e.g. 1.5")
+	}
+	if c.MaxGasPrices == "" {
+		// without a cap, the gas prices are never raised
+		if c.GasPriceBump != 0 {
+			add("max_gas_prices", "missing value", "required to bump the gas prices by gas_price_bump")
+		}
+		if len(c.GasStrategy.Ladder) > 0 {
+			add("max_gas_prices", "missing value", "required to raise the gas prices along gas_strategy.ladder")
+		}
+	}
+	switch c.GasStrategy.Mode {
+	case "", GasModeFixed, GasModeSimulate:
+	case GasModeNode:
+		if c.MaxGasPrices == "" {
+			add("max_gas_prices", "missing value", `required to cap the gas prices of the "node" gas strategy`)
+		}
+	default:
+		add("gas_strategy.mode", fmt.Sprintf("unknown gas strategy %q", c.GasStrategy.Mode),
+			`expected "fixed", "simulate" or "node"`)
+	}
+	if c.GasStrategy.GasAdjustment != 0 && c.GasStrategy.GasAdjustment < 1 {
+		add("gas_strategy.gas_adjustment", fmt.Sprintf("%v is out of range", c.GasStrategy.GasAdjustment),
+			"expected a factor of at least 1, e.g. 1.3")
+	}
+	if c.GasStrategy.Multiplier != 0 && c.GasStrategy.Multiplier < 1 {
+		add("gas_strategy.multiplier", fmt.Sprintf("%v is out of range", c.GasStrategy.Multiplier),
+			"expected a factor of at least 1, as the node rejects gas prices below its minimum")
+	}
+	for i, m := range c.GasStrategy.Ladder {
+		if m <= 1 || (i > 0 && m <= c.GasStrategy.Ladder[i-1]) {
+			add(fmt.Sprintf("gas_strategy.ladder[%d]", i), fmt.Sprintf("%v is out of order", m),
+				"expected increasing multipliers above 1, e.g. [1.5, 2, 3]")
+		}
+	}
+
+	if c.Account.Address != "" {
+		if _, err := sdk.AccAddressFromBech32(c.Account.Address); err != nil {
+			add("account.address", err.Error(), "expected an ojo1... bech32 address")
+		}
+	}
+
+	if c.RPC.RPCTimeout != "" {
+		if d, err := time.ParseDuration(c.RPC.RPCTimeout); err != nil || d <= 0 {
+			add("rpc.rpc_timeout", fmt.Sprintf("invalid duration %q", c.RPC.RPCTimeout),
+				`expected a positive duration, e.g. "10s"`)
+		}
+	}
+
+	if c.Relayer.Interval < 0 {
+		add("relayer.interval", "must be positive", `expected a duration, e.g. "24h"`)
+	}
+	if c.Relayer.Deviation < 0 || c.Relayer.Deviation > 1 {
+		add("relayer.deviation", fmt.Sprintf("%v is out of range", c.Relayer.Deviation),
+			"expected a fraction between 0 and 1, e.g. 0.01 for 1%")
+	}
+	if c.Relayer.Schedule != "" {
+		if _, err := cron.Parse(c.Relayer.Schedule); err != nil {
+			add("relayer.schedule", err.Error(), `expected a cron expression in UTC, e.g. "0 * * * *" for every hour at :00`)
+		}
+	}
+	if c.Relayer.Jitter < 0 {
+		add("relayer.jitter", "must not be negative", `expected a duration, e.g. "30s"`)
+	}
+	if c.Relayer.Piggyback < 0 || c.Relayer.Piggyback > 1 {
+		add("relayer.piggyback", fmt.Sprintf("%v is out of range", c.Relayer.Piggyback),
+			"expected a fraction of the thresholds between 0 and 1, e.g. 0.6")
+	}
+	if c.Relayer.PiggybackWindow < 0 {
+		add("relayer.piggyback_window", "must not be negative", `expected a duration, e.g. "1h"`)
+	}
+	if c.Relayer.MaxBatch < 0 {
+		add("relayer.max_batch", "must not be negative", "expected a number of assets, or 0 for no limit")
+	}
+	if c.Relayer.BatchWindow < 0 || c.Relayer.BatchWindowBlocks < 0 {
+		add("relayer.batch_window", "must not be negative", `expected a duration, e.g. "5s", or a number of blocks`)
+	}
+	if c.Relayer.UrgentDeviation < 0 || c.Relayer.UrgentDeviation > 1 {
+		add("relayer.urgent_deviation", fmt.Sprintf("%v is out of range", c.Relayer.UrgentDeviation),
+			"expected a fraction between 0 and 1, e.g. 0.05 for 5%")
+	}
+	if c.Relayer.MaxStaleness < 0 {
+		add("relayer.max_staleness", "must not be negative", `expected a duration, e.g. "24h"`)
+	}
+	if c.Relayer.DeliveryLatency < 0 {
+		add("relayer.delivery_latency", "must not be negative", `expected a duration, e.g. "10m"`)
+	}
+	if c.Relayer.Contract != "" {
+		if _, err := ChecksumAddress(c.Relayer.Contract); err != nil {
+			add("relayer.contract", err.Error(), addressHint)
+		}
+	}
+
+	errs = append(errs, c.Relayer.Callback.validate("relayer.callback")...)
+
+	if c.AxelarGas.Denom != "" {
+		if err := sdk.ValidateDenom(c.AxelarGas.Denom); err != nil {
+			add("axelar_gas.denom", err.Error(), "expected the IBC denom of AXL on Ojo, e.g. ibc/...")
+		}
+	}
+	if c.AxelarGas.Multiplier != "" {
+		if mul, err := math.LegacyNewDecFromStr(c.AxelarGas.Multiplier); err != nil || !mul.IsPositive() {
+			add("axelar_gas.multiplier", fmt.Sprintf("invalid multiplier %q", c.AxelarGas.Multiplier),
+				`expected a positive decimal, e.g. "1.2"`)
+		}
+	}
+	if c.AxelarGas.Default != "" {
+		if amt, ok := math.NewIntFromString(c.AxelarGas.Default); !ok || !amt.IsPositive() {
+			add("axelar_gas.default", fmt.Sprintf("invalid amount %q", c.AxelarGas.Default),
+				`expected a positive integer amount, e.g. "1000000"`)
+		}
+	}
+
+	switch c.Election.Mode {
+	case "":
+	case "file":
+		if c.Election.LockFile == "" {
+			add("election.lock_file", "missing value", `required by the "file" election mode`)
+		}
+	case "observe":
+		if _, err := sdk.AccAddressFromBech32(c.Election.LeaderAddress); err != nil {
+			add("election.leader_address", err.Error(), `the "observe" election mode requires the ojo1... address of the leader`)
+		}
+		if c.Election.Grace < 0 {
+			add("election.grace", "must not be negative", `expected a duration, e.g. "5m"`)
+		}
+	default:
+		add("election.mode", fmt.Sprintf("unknown election mode %q", c.Election.Mode), `expected "file" or "observe"`)
+	}
+
+	seen := map[string]bool{}
+	for i, a := range c.Assets {
+		if a.Denom == "" {
+			continue
+		}
+		if seen[a.Denom] {
+			add(fmt.Sprintf("assets[%d].denom", i), fmt.Sprintf("duplicate denom %q", a.Denom),
+				"remove the duplicate [[assets]] entry")
+		}
+		seen[a.Denom] = true
+
+		if a.MinPrice < 0 {
+			add(fmt.Sprintf("assets[%d].min_price", i), "must not be negative", "")
+		}
+		if a.MaxPrice < 0 {
+			add(fmt.Sprintf("assets[%d].max_price", i), "must not be negative", "")
+		}
+		if a.MaxPrice > 0 && a.MinPrice > a.MaxPrice {
+			add(fmt.Sprintf("assets[%d].min_price", i), fmt.Sprintf("%v is above max_price", a.MinPrice),
+				"expected min_price <= max_price")
+		}
+		if a.MaxStep < 0 {
+			add(fmt.Sprintf("assets[%d].max_step", i), "must not be negative",
+				"expected a fraction, e.g. 0.2 for 20%")
+		}
+		errs = append(errs, a.Reference.validate(fmt.Sprintf("assets[%d].reference", i))...)
+		errs = append(errs, a.Policy.validate(fmt.Sprintf("assets[%d].policy", i))...)
+		errs = append(errs, a.Smoothing.validate(fmt.Sprintf("assets[%d].smoothing", i))...)
+		errs = append(errs, a.Callback.validate(fmt.Sprintf("assets[%d].callback", i))...)
+		errs = append(errs, a.Composed.validate(fmt.Sprintf("assets[%d].composed", i))...)
+		if a.Composed.Type != "" && c.Relayer.EVMRPC == "" {
+			add("relayer.evm_rpc", "missing value", fmt.Sprintf("required to read the composed feed of %s", a.Denom))
+		}
+	}
+
+	pairs := map[string]bool{}
+	for i, p := range c.Pairs {
+		path := fmt.Sprintf("pairs[%d]", i)
+		if !c.HasAsset(p.Base) {
+			add(path+".base", fmt.Sprintf("unknown asset %q", p.Base), "both legs of a pair must be configured [[assets]]")
+		}
+		if !c.HasAsset(p.Quote) {
+			add(path+".quote", fmt.Sprintf("unknown asset %q", p.Quote), "both legs of a pair must be configured [[assets]]")
+		}
+		if p.Base == p.Quote {
+			add(path+".quote", "same as base", "")
+		}
+		if pairs[p.Name()] {
+			add(path, fmt.Sprintf("duplicate pair %s", p.Name()), "remove the duplicate [[pairs]] entry")
+		}
+		pairs[p.Name()] = true
+		if p.Deviation < 0 || p.Deviation > 1 {
+			add(path+".deviation", fmt.Sprintf("%v is out of range", p.Deviation),
+				"expected a fraction between 0 and 1, e.g. 0.02 for 2%")
+		}
+		if p.Heartbeat < 0 {
+			add(path+".heartbeat", "must not be negative", `expected a duration, e.g. "24h"`)
+		}
+	}
+
+	if n := len(c.Quorum.GRPCEndpoints); n > 0 {
+		if c.Quorum.MinAgree < 1 || c.Quorum.MinAgree > n {
+			add("quorum.min_agree", fmt.Sprintf("%d is out of range", c.Quorum.MinAgree),
+				fmt.Sprintf("expected between 1 and the %d grpc_endpoints, usually a majority", n))
+		}
+		if c.Quorum.Tolerance < 0 {
+			add("quorum.tolerance", "must not be negative", "expected a fraction, e.g. 0.001 for 0.1%")
+		}
+	}
+
+	if c.LightClient.Enabled() {
+		if bz, err := hex.DecodeString(c.LightClient.TrustedHash); err != nil || len(bz) != 32 {
+			add("light_client.trusted_hash", fmt.Sprintf("invalid hash %q", c.LightClient.TrustedHash),
+				"expected the 32 bytes hex hash of the block at trusted_height")
+		}
+		if c.LightClient.TrustingPeriod <= 0 {
+			add("light_client.trusting_period", "must be positive",
+				`expected a duration well below the unbonding period, e.g. "168h"`)
+		}
+		if len(c.LightClient.Witnesses) == 0 {
+			add("light_client.witnesses", "missing value", "expected at least one CometBFT RPC endpoint to cross-check the primary")
+		}
+		if len(c.Quorum.GRPCEndpoints) > 0 {
+			add("light_client", "cannot be used with quorum", "remove either the [quorum] or the [light_client] section")
+		}
+	} else if c.LightClient.TrustedHash != "" {
+		add("light_client.trusted_height", "missing value", "required with trusted_hash")
+	}
+
+	if c.Admin.ListenAddr != "" {
+		if _, _, err := net.SplitHostPort(c.Admin.ListenAddr); err != nil {
+			add("admin.listen_addr", err.Error(), `expected a host:port address, e.g. "127.0.0.1:7171"`)
+		}
+	}
+	if c.Alerts.Webhook != "" {
+		if u, err := url.Parse(c.Alerts.Webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			add("alerts.webhook", fmt.Sprintf("invalid url %q", c.Alerts.Webhook), "expected an http(s) URL")
+		}
+	}
+
+	errs = append(errs, c.Retry.PriceQuery.validate("retry.price_query")...)
+	errs = append(errs, c.Retry.FeeEstimate.validate("retry.fee_estimate")...)
+	errs = append(errs, c.Retry.Broadcast.validate("retry.broadcast")...)
+	errs = append(errs, c.Retry.Confirmation.validate("retry.confirmation")...)
+
+	return errs
+}
+
+// validate checks the backoff and attempts of a retry policy.
+func (r Retry) validate(path string) ValidationError {
+	var errs ValidationError
+	add := func(field, msg, hint string) {
+		errs = append(errs, FieldError{Field: path + "." + field, Message: msg, Hint: hint})
+	}
+
+	if r.Base < 0 {
+		add("base", "must not be negative", `expected a duration, e.g. "500ms"`)
+	}
+	if r.Max < 0 {
+		add("max", "must not be negative", `expected a duration, e.g. "10s"`)
+	} else if r.Max < r.Base {
+		add("max", fmt.Sprintf("%s is below base %s", r.Max, r.Base), "expected max >= base")
+	}
+	if r.Jitter < 0 || r.Jitter > 1 {
+		add("jitter", fmt.Sprintf("%v is out of range", r.Jitter), "expected a fraction between 0 and 1, e.g. 0.2 for 20%")
+	}
+	if r.MaxAttempts < 0 {
+		add("max_attempts", "must not be negative", "expected the number of calls including the first one, e.g. 3")
+	}
+	return errs
+}
+
+// validate checks the addresses required by the type of a composed feed.
+func (c Composed) validate(path string) ValidationError {
+	var errs ValidationError
+	add := func(field, msg, hint string) {
+		errs = append(errs, FieldError{Field: path + "." + field, Message: msg, Hint: hint})
+	}
+	checkAddress := func(field, address string) {
+		if _, err := ChecksumAddress(address); err != nil {
+			add(field, err.Error(), addressHint)
+		}
+	}
+
+	switch c.Type {
+	case "":
+		return nil
+	case "mellow", "yn":
+		checkAddress("contract", c.Contract)
+	case "inception":
+		checkAddress("contract", c.Contract)
+		checkAddress("token", c.Token)
+	default:
+		add("type", fmt.Sprintf("unknown composed feed type %q", c.Type), `expected "mellow", "inception" or "yn"`)
+	}
+	if c.Decimals < 0 || c.Decimals > 36 {
+		add("decimals", fmt.Sprintf("%d is out of range", c.Decimals), "expected the decimals of the rate, e.g. 18")
+	}
+	if c.Refresh < 0 {
+		add("refresh", "must not be negative", `expected a duration, e.g. "1m"`)
+	}
+	return errs
+}
+
+// callbackArgs are the arguments the Ojo contract calls a callback with.
+const callbackArgs = "(bytes32[],bytes)"
+
+// validate checks that a callback has a contract address, a
+// function taking the arguments of the Ojo contract and hex params.
+func (c Callback) validate(path string) ValidationError {
+	var errs ValidationError
+	add := func(field, msg, hint string) {
+		errs = append(errs, FieldError{Field: path + "." + field, Message: msg, Hint: hint})
+	}
+
+	if !c.Enabled() {
+		if c.Function != "" || c.Params != "" {
+			add("contract", "missing value", "required with function and params")
+		}
+		return errs
+	}
+
+	if _, err := ChecksumAddress(c.Contract); err != nil {
+		add("contract", err.Error(), addressHint)
+	}
+	sig := c.Signature()
+	if name, ok := strings.CutSuffix(sig, callbackArgs); !ok || name == "" || strings.ContainsAny(name, "(),") {
+		add("function", fmt.Sprintf("invalid function signature %q", c.Function),
+			fmt.Sprintf("the Ojo contract calls back with the relayed asset names and the params, e.g. \"rebalance%s\"", callbackArgs))
+	}
+	if _, err := c.ParamsBytes(); err != nil {
+		add("params", fmt.Sprintf("invalid hex %q", c.Params), "expected 0x prefixed, ABI encoded bytes")
+	}
+	return errs
+}
+
+// validate checks the fields required by the built-in reference sources.
+// Other types are checked when their source is created.
+func (r Reference) validate(path string) ValidationError {
+	var errs ValidationError
+	add := func(field, msg, hint string) {
+		errs = append(errs, FieldError{Field: path + "." + field, Message: msg, Hint: hint})
+	}
+
+	switch r.Type {
+	case "":
+		return nil
+	case "ojo":
+		if r.GRPCEndpoint == "" {
+			add("grpc_endpoint", "missing value", `required by the "ojo" reference type`)
+		}
+	case "http":
+		if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			add("url", fmt.Sprintf("invalid url %q", r.URL), "expected an http(s) URL")
+		}
+		if r.JSONPath == "" {
+			add("json_path", "missing value", `expected the path of the price in the response, e.g. "data.price"`)
+		}
+	}
+	if r.Tolerance <= 0 {
+		add("tolerance", "must be positive", "expected a fraction, e.g. 0.02 for 2%")
+	}
+	if r.Timeout < 0 {
+		add("timeout", "must not be negative", `expected a duration, e.g. "5s"`)
+	}
+	return errs
+}
+
+// validate checks the built-in policy types and their composition. Other
+// types are checked when their policy is created.
+func (p Policy) validate(path string) ValidationError {
+	var errs ValidationError
+	add := func(field, msg, hint string) {
+		errs = append(errs, FieldError{Field: path + field, Message: msg, Hint: hint})
+	}
+
+	switch p.Type {
+	case "and", "or":
+		if len(p.Policies) == 0 {
+			add(".policies", "missing value", fmt.Sprintf("the %q policy type composes at least one nested policy", p.Type))
+		}
+	case "heartbeat", "deviation":
+		if len(p.Policies) > 0 {
+			add(".policies", "unexpected value", `only the "and" and "or" policy types compose nested policies`)
+		}
+	case "adaptive":
+		if p.MinThreshold <= 0 || p.MaxThreshold < p.MinThreshold {
+			add(".min_threshold", fmt.Sprintf("invalid bounds [%v, %v]", p.MinThreshold, p.MaxThreshold),
+				"expected 0 < min_threshold <= max_threshold, e.g. 0.005 and 0.05")
+		}
+		if p.Window <= 0 {
+			add(".window", "must be positive", `expected the volatility window, e.g. "1h"`)
+		}
+		if p.Peg < 0 || p.DepegBand < 0 || p.StressThreshold < 0 {
+			add(".peg", "peg, depeg_band and stress_threshold must not be negative", "")
+		}
+		if p.Peg > 0 && p.DepegBand == 0 {
+			add(".depeg_band", "missing value", "required with peg, e.g. 0.005 for 0.5%")
+		}
+	}
+	if p.Interval < 0 {
+		add(".interval", "must not be negative", `expected a duration, e.g. "1h"`)
+	}
+	if p.Schedule != "" {
+		if p.Type != "heartbeat" {
+			add(".schedule", "unexpected value", `only the "heartbeat" policy type has a schedule`)
+		} else if _, err := cron.Parse(p.Schedule); err != nil {
+			add(".schedule", err.Error(), `expected a cron expression in UTC, e.g. "0 * * * *" for every hour at :00`)
+		}
+	}
+	if p.Jitter < 0 {
+		add(".jitter", "must not be negative", `expected a duration, e.g. "30s"`)
+	}
+	if p.Threshold < 0 {
+		add(".threshold", "must not be negative", "expected a fraction, e.g. 0.01 for 1%")
+	}
+
+	for i, nested := range p.Policies {
+		errs = append(errs, nested.validate(fmt.Sprintf("%s.policies[%d]", path, i))...)
+	}
+	return errs
+}
+
+func (sm Smoothing) validate(path string) ValidationError {
+	var errs ValidationError
+	add := func(field, msg, hint string) {
+		errs = append(errs, FieldError{Field: path + "." + field, Message: msg, Hint: hint})
+	}
+
+	switch sm.Type {
+	case "":
+	case "ema", "twap":
+		if sm.Window <= 0 {
+			add("window", "must be positive", `expected a duration, e.g. "5m"`)
+		}
+	default:
+		add("type", fmt.Sprintf("unknown smoothing type %q", sm.Type), `expected "ema" or "twap"`)
+	}
+	if sm.Samples < 0 {
+		add("samples", "must not be negative", "")
+	}
+	if sm.Confirmations < 0 {
+		add("confirmations", "must not be negative", "")
+	}
+	return errs
+}
+
+// addressHint is the hint of the malformed addresses of the destination.
+const addressHint = "expected a 0x prefixed, 20 bytes hex address"
+
+// ValidateDestination returns an error if the destination is not one of the
+// known axelar chains, or if a contract address of the destination is not
+// EIP-55 checksummed. It is only checked by "config validate", as axelar
+// adds chains independently of the relayer releases and the relayer accepts
+// addresses in any case.
+func (c Config) ValidateDestination() ValidationError {
+	var errs ValidationError
+	if c.Relayer.Destination != "" && !IsKnownDestination(c.Relayer.Destination) {
+		errs = append(errs, FieldError{
+			Field:   "relayer.destination",
+			Message: fmt.Sprintf("unknown destination chain %q", c.Relayer.Destination),
+			Hint:    fmt.Sprintf("expected an axelar chain name, one of: %s", strings.Join(KnownDestinations(), ", ")),
+		})
+	}
+
+	checkChecksum := func(field, address string) {
+		if address == "" {
+			return
+		}
+		checksummed, err := ChecksumAddress(address)
+		if err != nil || checksummed == address {
+			// malformed addresses are reported by Validate
+			return
+		}
+		errs = append(errs, FieldError{
+			Field:   field,
+			Message: "address is not checksummed",
+			Hint:    fmt.Sprintf("did you mean %s?", checksummed),
+		})
+	}
+	checkChecksum("relayer.contract", c.Relayer.Contract)
+	checkChecksum("relayer.callback.contract", c.Relayer.Callback.Contract)
+	for i, a := range c.Assets {
+		checkChecksum(fmt.Sprintf("assets[%d].callback.contract", i), a.Callback.Contract)
+		checkChecksum(fmt.Sprintf("assets[%d].composed.contract", i), a.Composed.Contract)
+		checkChecksum(fmt.Sprintf("assets[%d].composed.token", i), a.Composed.Token)
+	}
+	return errs
+}
+
+// ChecksumAddress returns the EIP-55 checksummed form of a hex address.
+func ChecksumAddress(address string) (string, error) {
+	hexAddr, ok := strings.CutPrefix(address, "0x")
+	if !ok || len(hexAddr) != 40 {
+		return "", fmt.Errorf("invalid address %q", address)
+	}
+	if _, err := hex.DecodeString(hexAddr); err != nil {
+		return "", fmt.Errorf("invalid address %q", address)
+	}
+
+	lower := strings.ToLower(hexAddr)
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(lower))
+	digest := hash.Sum(nil)
+
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		// uppercase letters whose matching hash nibble is >= 8
+		nibble := digest[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c > '9' && nibble&0xf >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(checksummed), nil
+}
+
+// ValidateChecksumAddress returns an error if the address is not a valid
+// EIP-55 checksummed hex address.
+func ValidateChecksumAddress(address string) error {
+	checksummed, err := ChecksumAddress(address)
+	if err != nil {
+		return err
+	}
+	if checksummed != address {
+		return errors.New("address is not checksummed")
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo/app/params"
+)
+
+func validConfig() Config {
+	return Config{
+		Account: Account{
+			ChainID: "agamotto",
+			Address: "ojo1kjqcup59v5jtlykewz90em6v0cz7tqpd7u7nyr",
+		},
+		Keyring:   Keyring{Backend: "test", Dir: "/tmp"},
+		RPC:       RPC{TMRPCEndpoint: "http://localhost:26657", GRPCEndpoint: "localhost:9090", RPCTimeout: "100ms"},
+		Gas:       1000000,
+		GasPrices: "0.025uojo",
+		Relayer: Relayer{
+			Interval:    24 * time.Hour,
+			Deviation:   0.05,
+			Destination: "Arbitrum",
+			Contract:    "0x5BB3E85f91D08fe92a3D123EE35050b763D6E6A7",
+		},
+		Assets:    []Assets{{Denom: "BTC"}, {Denom: "ETH"}},
+		AxelarGas: AxelarGas{Denom: "ibc/xyz", Multiplier: "1.2", Default: "1000000"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	params.SetAddressPrefixes()
+
+	tests := []struct {
+		name       string
+		mutate     func(*Config)
+		wantFields []string
+	}{
+		{
+			name:   "valid config",
+			mutate: func(*Config) {},
+		},
+		{
+			name:       "missing chain id",
+			mutate:     func(c *Config) { c.Account.ChainID = "" },
+			wantFields: []string{"account.chain_id"},
+		},
+		{
+			name: "unparseable values",
+			mutate: func(c *Config) {
+				c.GasPrices = "uojo"
+				c.RPC.RPCTimeout = "100"
+				c.AxelarGas.Multiplier = "x1.2"
+			},
+			wantFields: []string{"gas_prices", "rpc.rpc_timeout", "axelar_gas.multiplier"},
+		},
+		{
+			// unknown destinations and unchecksummed addresses are only
+			// reported by ValidateDestination
+			name: "unknown destination and unchecksummed contract",
+			mutate: func(c *Config) {
+				c.Relayer.Destination = "Arbitrun"
+				c.Relayer.Contract = "0x5bb3e85f91d08fe92a3d123ee35050b763d6e6a7"
+			},
+		},
+		{
+			name:       "malformed contract",
+			mutate:     func(c *Config) { c.Relayer.Contract = "0x5bb3e85f" },
+			wantFields: []string{"relayer.contract"},
+		},
+		{
+			name:       "duplicate asset",
+			mutate:     func(c *Config) { c.Assets = append(c.Assets, Assets{Denom: "BTC"}) },
+			wantFields: []string{"assets[2].denom"},
+		},
+		{
+			name: "inverted price bounds",
+			mutate: func(c *Config) {
+				c.Assets[0].MinPrice = 100
+				c.Assets[0].MaxPrice = 10
+			},
+			wantFields: []string{"assets[0].min_price"},
+		},
+		{
+			name: "invalid schedules",
+			mutate: func(c *Config) {
+				c.Relayer.Schedule = "0 * * *"
+				c.Assets[0].Policy = Policy{Type: "deviation", Schedule: "@hourly"}
+			},
+			wantFields: []string{"relayer.schedule", "assets[0].policy.schedule"},
+		},
+		{
+			name: "invalid callback",
+			mutate: func(c *Config) {
+				c.Relayer.Callback = Callback{
+					Contract: "0x5BB3E85f91D08fe92a3D123EE35050b763D6E6A7",
+					Function: "rebalance(address)",
+					Params:   "0xzz",
+				}
+			},
+			wantFields: []string{"relayer.callback.function", "relayer.callback.params"},
+		},
+		{
+			name:       "pair of unknown asset",
+			mutate:     func(c *Config) { c.Pairs = []Pair{{Base: "WETH", Quote: "ETH"}} },
+			wantFields: []string{"pairs[0].base"},
+		},
+		{
+			name: "invalid gas price bump",
+			mutate: func(c *Config) {
+				c.MaxGasPrices = "0.01uojo"
+				c.GasPriceBump = 0.5
+			},
+			wantFields: []string{"max_gas_prices", "gas_price_bump"},
+		},
+		{
+			name: "gas escalation without max gas prices",
+			mutate: func(c *Config) {
+				c.GasPriceBump = 2
+				c.GasStrategy.Ladder = []float64{1.5, 2}
+			},
+			wantFields: []string{"max_gas_prices", "max_gas_prices"},
+		},
+		{
+			name: "invalid gas strategy",
+			mutate: func(c *Config) {
+				c.MaxGasPrices = "0.1uojo"
+				c.GasStrategy = GasStrategy{Mode: "node", Multiplier: 0.5, Ladder: []float64{2, 1.5}}
+			},
+			wantFields: []string{"gas_strategy.multiplier", "gas_strategy.ladder[1]"},
+		},
+		{
+			name:       "node gas strategy without max gas prices",
+			mutate:     func(c *Config) { c.GasStrategy.Mode = "node" },
+			wantFields: []string{"max_gas_prices"},
+		},
+		{
+			name: "invalid retry",
+			mutate: func(c *Config) {
+				c.Retry.Broadcast = Retry{Base: 10 * time.Second, Max: time.Second, Jitter: 1.5}
+			},
+			wantFields: []string{"retry.broadcast.max", "retry.broadcast.jitter"},
+		},
+		{
+			name: "incomplete light client",
+			mutate: func(c *Config) {
+				c.LightClient.TrustedHeight = 100
+				c.LightClient.TrustedHash = "abcd"
+			},
+			wantFields: []string{"light_client.trusted_hash", "light_client.trusting_period", "light_client.witnesses"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(&cfg)
+
+			err := cfg.Validate()
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var fieldErrs ValidationError
+			if !errors.As(err, &fieldErrs) {
+				t.Fatalf("Validate() error = %v, want ValidationError", err)
+			}
+			if len(fieldErrs) != len(tt.wantFields) {
+				t.Fatalf("Validate() errors = %v, want fields %v", fieldErrs, tt.wantFields)
+			}
+			for i, field := range tt.wantFields {
+				if fieldErrs[i].Field != field {
+					t.Errorf("Validate() field = %v, want %v", fieldErrs[i].Field, field)
+				}
+			}
+		})
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	got, err := ChecksumAddress("0x5bb3e85f91d08fe92a3d123ee35050b763d6e6a7")
+	if err != nil {
+		t.Fatalf("ChecksumAddress() error = %v", err)
+	}
+	if want := "0x5BB3E85f91D08fe92a3D123EE35050b763D6E6A7"; got != want {
+		t.Errorf("ChecksumAddress() = %v, want %v", got, want)
+	}
+
+	if _, err := ChecksumAddress("0x001"); err == nil {
+		t.Errorf("ChecksumAddress() expected error for short address")
+	}
+}
+
+func TestValidateDestination(t *testing.T) {
+	c := validConfig()
+	if errs := c.ValidateDestination(); len(errs) != 0 {
+		t.Errorf("ValidateDestination() = %v, want no error", errs)
+	}
+	c.Relayer.Destination = "Arbitrun"
+	c.Relayer.Contract = "0x5bb3e85f91d08fe92a3d123ee35050b763d6e6a7"
+	c.Assets[0].Callback.Contract = "0x5BB3E85F91D08FE92A3D123EE35050B763D6E6A7"
+	errs := c.ValidateDestination()
+	want := []string{"relayer.destination", "relayer.contract", "assets[0].callback.contract"}
+	if len(errs) != len(want) {
+		t.Fatalf("ValidateDestination() = %v, want %v", errs, want)
+	}
+	for i := range want {
+		if errs[i].Field != want[i] {
+			t.Errorf("ValidateDestination() field = %s, want %s", errs[i].Field, want[i])
+		}
+	}
+}
+
+func TestGenerateConfig(t *testing.T) {
+	if _, err := GenerateConfig("testnet", "", ""); err != nil {
+		t.Errorf("GenerateConfig(testnet) error = %v", err)
+	}
+	// mainnet has no preset chain ID and AXL denom
+	if _, err := GenerateConfig("mainnet", "", ""); err == nil {
+		t.Errorf("GenerateConfig(mainnet) expected error without chain ID")
+	}
+	bz, err := GenerateConfig("mainnet", "ojo-1", "ibc/AXL")
+	if err != nil {
+		t.Fatalf("GenerateConfig(mainnet) error = %v", err)
+	}
+	if s := string(bz); !strings.Contains(s, `chain_id = "ojo-1"`) || !strings.Contains(s, `denom = "ibc/AXL"`) {
+		t.Errorf("GenerateConfig(mainnet) does not set the chain ID and AXL denom:\n%s", s)
+	}
+	if _, err := GenerateConfig("devnet", "", ""); err == nil {
+		t.Errorf("GenerateConfig() expected error for unknown network")
+	}
+}
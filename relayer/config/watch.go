@@ -0,0 +1,114 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long to wait for writes to settle before reloading.
+const watchDebounce = 500 * time.Millisecond
+
+// Watch reloads the config from the node config file path whenever the file
+// or a file of its config_dir, subdirectories included, changes, or a value
+// is received on trigger, and passes the result of the reload to onReload.
+// It blocks until the context is done.
+func Watch(
+	ctx context.Context,
+	nodeConfigPath string,
+	dirPrefix string,
+	trigger <-chan struct{},
+	onReload func(Config, error),
+) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	nodeConfigPath = filepath.Clean(nodeConfigPath)
+	nodeConfigDir := filepath.Dir(nodeConfigPath)
+	// configDirs are the watched directories of the config_dir
+	configDirs := map[string]bool{}
+
+	// watch the directory of the node config file rather than the file
+	// itself, as editors usually replace the file on save
+	if err := watcher.Add(nodeConfigDir); err != nil {
+		return err
+	}
+	// fragments are merged from the subdirectories of config_dir too, which
+	// are watched one by one as they are created and removed
+	watchConfigDir := func() {
+		dir, err := parseConfigDir(nodeConfigPath)
+		if err != nil {
+			return
+		}
+		dirs := map[string]bool{}
+		if dir != "" {
+			dirs = subdirectories(filepath.Clean(dirPrefix + dir))
+		}
+		for d := range configDirs {
+			if !dirs[d] {
+				if d != nodeConfigDir {
+					_ = watcher.Remove(d)
+				}
+				delete(configDirs, d)
+			}
+		}
+		for d := range dirs {
+			if !configDirs[d] && watcher.Add(d) == nil {
+				configDirs[d] = true
+			}
+		}
+	}
+	watchConfigDir()
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-trigger:
+			debounce.Reset(0)
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			name := filepath.Clean(event.Name)
+			if name == nodeConfigPath || configDirs[filepath.Dir(name)] {
+				debounce.Reset(watchDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			onReload(Config{}, err)
+
+		case <-debounce.C:
+			watchConfigDir()
+			onReload(LoadConfigFromFlags(nodeConfigPath, dirPrefix))
+		}
+	}
+}
+
+// subdirectories returns the given directory and its subdirectories, as
+// walked by the config loading.
+func subdirectories(root string) map[string]bool {
+	dirs := map[string]bool{}
+	_ = filepath.Walk(root, func(path string, info os.FileInfo, _ error) error {
+		if info != nil && info.IsDir() {
+			dirs[filepath.Clean(path)] = true
+		}
+		return nil
+	})
+	return dirs
+}
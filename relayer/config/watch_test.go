@@ -0,0 +1,52 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestWatchSubdirectories(t *testing.T) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Skipf("cannot watch files: %v", err)
+	}
+	watcher.Close()
+
+	dir := t.TempDir()
+	nodeConfigPath := filepath.Join(dir, "relayer.toml")
+	if err := os.WriteFile(nodeConfigPath, []byte("config_dir = \"conf\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(dir, "conf", "assets")
+	if err := os.MkdirAll(sub, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads := make(chan struct{}, 1)
+	go func() {
+		_ = Watch(ctx, nodeConfigPath, dir+"/", nil, func(Config, error) {
+			select {
+			case reloads <- struct{}{}:
+			default:
+			}
+		})
+	}()
+	// let the watcher add the directories
+	time.Sleep(100 * time.Millisecond)
+
+	if err := os.WriteFile(filepath.Join(sub, "btc.toml"), []byte("[[assets]]\ndenom = \"BTC\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch() did not reload on a change in a subdirectory of config_dir")
+	}
+}
@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/relayer"
+	"github.com/rs/zerolog"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// Relayer defines the relayer operations exposed by the admin API.
+type Relayer interface {
+	Status() relayer.Status
+	ClearHalt(denom string) error
+}
+
+// Server defines the admin HTTP API of the relayer. It serves:
+//
+//	GET    /status        the state of the relayer and its halted denoms
+//	GET    /halts         the denoms halted by the circuit breaker
+//	DELETE /halts/{denom} clears the halt of a denom
+type Server struct {
+	logger  zerolog.Logger
+	relayer Relayer
+	token   string
+	srv     *http.Server
+}
+
+// NewServer returns an admin server listening on the given address. If token
+// is not empty, every request must carry it as a bearer token.
+func NewServer(logger zerolog.Logger, listenAddr, token string, r Relayer) *Server {
+	s := &Server{
+		logger:  logger.With().Str("module", "admin").Logger(),
+		relayer: r,
+		token:   token,
+	}
+	s.srv = &http.Server{
+		Addr:              listenAddr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	return s
+}
+
+// Handler returns the HTTP handler of the admin API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/halts", s.handleHalts)
+	mux.HandleFunc("/halts/", s.handleHalt)
+	return s.authorize(mux)
+}
+
+// Start serves the admin API until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		s.logger.Info().Str("listen_addr", s.srv.Addr).Msg("starting admin server")
+		errCh <- s.srv.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return s.srv.Shutdown(shutdownCtx)
+
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if s.token != "" {
+			token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+				writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+				return
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.relayer.Status())
+}
+
+func (s *Server) handleHalts(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.relayer.Status().Halts)
+}
+
+func (s *Server) handleHalt(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	denom := strings.TrimPrefix(req.URL.Path, "/halts/")
+	if denom == "" || strings.Contains(denom, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if err := s.relayer.ClearHalt(denom); err != nil {
+		if errors.Is(err, relayer.ErrNotHalted) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.logger.Info().Str("denom", denom).Str("remote_addr", req.RemoteAddr).Msg("halt cleared through the admin api")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]string{"error": msg})
+}
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ojo-network/ojo-evm/relayer/relayer"
+	"github.com/rs/zerolog"
+)
+
+type fakeRelayer struct {
+	halts []relayer.Halt
+}
+
+func (f *fakeRelayer) Status() relayer.Status {
+	return relayer.Status{Halts: f.halts}
+}
+
+func (f *fakeRelayer) ClearHalt(denom string) error {
+	for i, h := range f.halts {
+		if h.Denom == denom {
+			f.halts = append(f.halts[:i], f.halts[i+1:]...)
+			return nil
+		}
+	}
+	return relayer.ErrNotHalted
+}
+
+func TestServer(t *testing.T) {
+	r := &fakeRelayer{halts: []relayer.Halt{{Denom: "BTC", Reason: "price 0 is not positive"}}}
+	handler := NewServer(zerolog.Nop(), "", "secret", r).Handler()
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/status", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("GET /status without token = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	rec := do(http.MethodGet, "/halts", "secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /halts = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var halts []relayer.Halt
+	if err := json.Unmarshal(rec.Body.Bytes(), &halts); err != nil || len(halts) != 1 {
+		t.Errorf("GET /halts = %s, want the BTC halt", rec.Body)
+	}
+
+	if rec := do(http.MethodGet, "/halts/BTC", "secret"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /halts/BTC = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+	if rec := do(http.MethodDelete, "/halts/BTC", "secret"); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE /halts/BTC = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if rec := do(http.MethodDelete, "/halts/BTC", "secret"); rec.Code != http.StatusNotFound {
+		t.Errorf("DELETE /halts/BTC twice = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,70 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+)
+
+const alertTimeout = 10 * time.Second
+
+// Alert defines an event that requires the attention of an operator.
+type Alert struct {
+	Kind    string    `json:"kind"`
+	Denom   string    `json:"denom,omitempty"`
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// alert logs the given alert, counts it and posts it to the configured
+// webhook, if any, without blocking the relayer.
+func (r *Relayer) alert(a Alert) {
+	a.Time = time.Now()
+
+	r.logger.Error().
+		Str("alert", a.Kind).
+		Str("denom", a.Denom).
+		Msg(a.Message)
+	telemetry.IncrCounter(1, "alert", a.Kind)
+
+	if webhook := r.cfg.Alerts.Webhook; webhook != "" {
+		go func() {
+			if err := postAlert(webhook, a); err != nil {
+				r.logger.Err(err).Str("alert", a.Kind).Msg("unable to post alert to webhook")
+			}
+		}()
+	}
+}
+
+// postAlert posts an alert as JSON to the given webhook.
+func postAlert(webhook string, a Alert) error {
+	bz, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
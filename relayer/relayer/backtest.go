@@ -0,0 +1,316 @@
+package relayer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+)
+
+// PricePoint defines a single historical price observation of a denom.
+type PricePoint struct {
+	Time  time.Time
+	Denom string
+	Price float64
+}
+
+// BacktestParams defines a set of relay parameters to replay a price series through.
+type BacktestParams struct {
+	Name        string
+	Destination string
+	Interval    time.Duration
+	Deviation   float64
+	// Fee is the estimated fee paid for each relay tx, denominated in the
+	// axelar gas denom.
+	Fee float64
+	// Relayer is the relayer section of the config, whose interval and
+	// deviation are replaced by Interval and Deviation.
+	Relayer config.Relayer
+	// Assets are the assets of the config, whose relay policies and
+	// smoothing are replayed. Denoms without an asset use the default
+	// policy.
+	Assets []config.Assets
+}
+
+// AssetReport defines the outcome of a backtest for a single denom.
+type AssetReport struct {
+	Denom           string
+	Relays          int
+	HeartbeatRelays int
+	DeviationRelays int
+	// MaxStaleness is the longest time observed between the last relay of
+	// the denom and a tick.
+	MaxStaleness time.Duration
+	// MaxUnrelayedDeviation is the largest deviation from the last relayed
+	// price that did not trigger a relay.
+	MaxUnrelayedDeviation float64
+	// FeeCost is the share of the relay fees attributable to this denom.
+	// Fees of a batched relay are split evenly between its denoms.
+	FeeCost float64
+}
+
+// BacktestReport defines the outcome of a backtest for a set of parameters.
+type BacktestReport struct {
+	Params  BacktestParams
+	Ticks   int
+	Relays  int
+	FeeCost float64
+	Assets  []AssetReport
+}
+
+// simAsset is the simulated in-memory state of an asset.
+type simAsset struct {
+	asset
+	price       float64
+	initialized bool
+	report      AssetReport
+}
+
+// Backtest replays a price series through the relay policies used by the
+// relayer tick, using a simulated clock. If step is zero, a tick
+// is simulated at every distinct timestamp of the series; otherwise ticks are
+// simulated every step from the first observation, using the latest
+// observed price of each denom.
+func Backtest(series []PricePoint, params BacktestParams, step time.Duration) (BacktestReport, error) {
+	if len(series) == 0 {
+		return BacktestReport{}, errors.New("empty price series")
+	}
+	if params.Interval <= 0 {
+		return BacktestReport{}, fmt.Errorf("invalid interval: %s", params.Interval)
+	}
+	if params.Deviation <= 0 {
+		return BacktestReport{}, fmt.Errorf("invalid deviation: %v", params.Deviation)
+	}
+	if step < 0 {
+		return BacktestReport{}, fmt.Errorf("invalid step: %s", step)
+	}
+
+	defaults := params.Relayer
+	defaults.Interval, defaults.Deviation = params.Interval, params.Deviation
+	policies, err := policy.NewPolicies(params.Assets, defaults)
+	if err != nil {
+		return BacktestReport{}, err
+	}
+
+	points := make([]PricePoint, len(series))
+	copy(points, series)
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Before(points[j].Time)
+	})
+
+	report := BacktestReport{Params: params}
+	assets := []*simAsset{}
+	byDenom := map[string]*simAsset{}
+
+	next := 0
+	for _, now := range tickTimes(points, step) {
+		// apply every observation up to the simulated clock
+		for ; next < len(points) && !points[next].Time.After(now); next++ {
+			p := points[next]
+			a, ok := byDenom[p.Denom]
+			if !ok {
+				if _, ok := policies[p.Denom]; !ok {
+					defaultPolicy, err := policy.Default(defaults)
+					if err != nil {
+						return BacktestReport{}, err
+					}
+					policies[p.Denom] = defaultPolicy
+				}
+				a = &simAsset{
+					asset:  asset{denom: p.Denom},
+					report: AssetReport{Denom: p.Denom},
+				}
+				byDenom[p.Denom] = a
+				assets = append(assets, a)
+			}
+			a.price = p.Price
+		}
+
+		report.Ticks++
+		batch := []*simAsset{}
+		for _, a := range assets {
+			// first observation of the asset, relay it as init does
+			if !a.initialized {
+				batch = append(batch, a)
+				continue
+			}
+
+			if staleness := now.Sub(a.lastRelay); staleness > a.report.MaxStaleness {
+				a.report.MaxStaleness = staleness
+			}
+
+			history := policy.History{Denom: a.denom, LastPrice: a.lastPrice, LastRelay: a.lastRelay}
+			d := policies[a.denom].Decide(history, a.price, now)
+			if d.Relay {
+				// relays due by now are heartbeats, the others deviations
+				if !d.Due.IsZero() && !now.Before(d.Due) {
+					a.report.HeartbeatRelays++
+				} else {
+					a.report.DeviationRelays++
+				}
+				batch = append(batch, a)
+				continue
+			}
+			if pct := policy.Change(a.lastPrice, a.price); pct > a.report.MaxUnrelayedDeviation {
+				a.report.MaxUnrelayedDeviation = pct
+			}
+		}
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		report.Relays++
+		report.FeeCost += params.Fee
+		share := params.Fee / float64(len(batch))
+		for _, a := range batch {
+			a.initialized = true
+			a.lastPrice = a.price
+			a.lastRelay = now
+			a.report.Relays++
+			a.report.FeeCost += share
+		}
+	}
+
+	report.Assets = make([]AssetReport, len(assets))
+	for i, a := range assets {
+		report.Assets[i] = a.report
+	}
+
+	return report, nil
+}
+
+// tickTimes returns the simulated tick times for a sorted price series.
+func tickTimes(points []PricePoint, step time.Duration) []time.Time {
+	start, end := points[0].Time, points[len(points)-1].Time
+
+	ticks := []time.Time{}
+	if step > 0 {
+		for t := start; !t.After(end); t = t.Add(step) {
+			ticks = append(ticks, t)
+		}
+		return ticks
+	}
+
+	for _, p := range points {
+		if len(ticks) == 0 || p.Time.After(ticks[len(ticks)-1]) {
+			ticks = append(ticks, p.Time)
+		}
+	}
+	return ticks
+}
+
+// LoadPriceSeries reads a price series from a CSV or JSON file, depending on
+// its extension.
+//
+// CSV files must have a "timestamp,denom,price" header. JSON files must
+// contain an array of {"timestamp", "denom", "price"} objects. Timestamps
+// are either RFC3339 strings or unix seconds.
+func LoadPriceSeries(path string) ([]PricePoint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		return parsePriceSeriesCSV(f)
+	case ".json":
+		return parsePriceSeriesJSON(f)
+	default:
+		return nil, fmt.Errorf("unsupported price series format: %s", ext)
+	}
+}
+
+func parsePriceSeriesCSV(r io.Reader) ([]PricePoint, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("empty price series")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"timestamp", "denom", "price"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing %s column in price series header", name)
+		}
+	}
+
+	points := make([]PricePoint, 0, len(records)-1)
+	for i, record := range records[1:] {
+		ts, err := parseTimestamp(record[columns["timestamp"]])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(record[columns["price"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid price: %w", i+2, err)
+		}
+		points = append(points, PricePoint{
+			Time:  ts,
+			Denom: strings.TrimSpace(record[columns["denom"]]),
+			Price: price,
+		})
+	}
+
+	return points, nil
+}
+
+func parsePriceSeriesJSON(r io.Reader) ([]PricePoint, error) {
+	var raw []struct {
+		Timestamp json.RawMessage `json:"timestamp"`
+		Denom     string          `json:"denom"`
+		Price     json.Number     `json:"price"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	points := make([]PricePoint, 0, len(raw))
+	for i, v := range raw {
+		ts, err := parseTimestamp(strings.Trim(string(v.Timestamp), `"`))
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		price, err := v.Price.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: invalid price: %w", i, err)
+		}
+		points = append(points, PricePoint{
+			Time:  ts,
+			Denom: v.Denom,
+			Price: price,
+		})
+	}
+
+	return points, nil
+}
+
+// parseTimestamp parses either an RFC3339 timestamp or unix seconds.
+func parseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0).UTC(), nil
+	}
+	ts, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	return ts, nil
+}
@@ -0,0 +1,162 @@
+package relayer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+func TestBacktest(t *testing.T) {
+	start := time.Unix(1700000000, 0).UTC()
+	series := []PricePoint{
+		{Time: start, Denom: "BTC", Price: 100},
+		{Time: start, Denom: "ETH", Price: 10},
+		{Time: start.Add(time.Minute), Denom: "BTC", Price: 103},
+		{Time: start.Add(2 * time.Minute), Denom: "BTC", Price: 106},
+		{Time: start.Add(3 * time.Minute), Denom: "ETH", Price: 10.1},
+		{Time: start.Add(5 * time.Minute), Denom: "ETH", Price: 10.2},
+	}
+	params := BacktestParams{
+		Interval:  4 * time.Minute,
+		Deviation: 0.05,
+		Fee:       10,
+	}
+
+	got, err := Backtest(series, params, 0)
+	if err != nil {
+		t.Fatalf("Backtest() error = %v", err)
+	}
+
+	// init relay, BTC deviation relay at 2m and ETH heartbeat relay at 5m
+	if got.Ticks != 5 {
+		t.Errorf("Backtest() ticks = %v, want %v", got.Ticks, 5)
+	}
+	if got.Relays != 3 {
+		t.Errorf("Backtest() relays = %v, want %v", got.Relays, 3)
+	}
+	if got.FeeCost != 30 {
+		t.Errorf("Backtest() fee cost = %v, want %v", got.FeeCost, 30)
+	}
+
+	want := []AssetReport{
+		{
+			Denom:                 "BTC",
+			Relays:                2,
+			DeviationRelays:       1,
+			MaxStaleness:          3 * time.Minute,
+			MaxUnrelayedDeviation: 0.03,
+			FeeCost:               15,
+		},
+		{
+			Denom:                 "ETH",
+			Relays:                2,
+			HeartbeatRelays:       1,
+			MaxStaleness:          5 * time.Minute,
+			MaxUnrelayedDeviation: 0.01,
+			FeeCost:               15,
+		},
+	}
+	if len(got.Assets) != len(want) {
+		t.Fatalf("Backtest() assets = %v, want %v", got.Assets, want)
+	}
+	for i := range want {
+		g, w := got.Assets[i], want[i]
+		if g.Denom != w.Denom || g.Relays != w.Relays ||
+			g.HeartbeatRelays != w.HeartbeatRelays || g.DeviationRelays != w.DeviationRelays ||
+			g.MaxStaleness != w.MaxStaleness || g.FeeCost != w.FeeCost {
+			t.Errorf("Backtest() asset = %+v, want %+v", g, w)
+		}
+		if diff := g.MaxUnrelayedDeviation - w.MaxUnrelayedDeviation; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("Backtest() max unrelayed deviation = %v, want %v", g.MaxUnrelayedDeviation, w.MaxUnrelayedDeviation)
+		}
+	}
+}
+
+func TestBacktestStep(t *testing.T) {
+	start := time.Unix(1700000000, 0).UTC()
+	series := []PricePoint{
+		{Time: start, Denom: "BTC", Price: 100},
+		{Time: start.Add(10 * time.Minute), Denom: "BTC", Price: 100},
+	}
+	params := BacktestParams{
+		Interval:  2 * time.Minute,
+		Deviation: 0.05,
+	}
+
+	got, err := Backtest(series, params, time.Minute)
+	if err != nil {
+		t.Fatalf("Backtest() error = %v", err)
+	}
+	if got.Ticks != 11 {
+		t.Errorf("Backtest() ticks = %v, want %v", got.Ticks, 11)
+	}
+	// init relay and a heartbeat every 2 minutes
+	if got.Relays != 6 {
+		t.Errorf("Backtest() relays = %v, want %v", got.Relays, 6)
+	}
+	if got.Assets[0].MaxStaleness != 2*time.Minute {
+		t.Errorf("Backtest() max staleness = %v, want %v", got.Assets[0].MaxStaleness, 2*time.Minute)
+	}
+}
+
+func TestBacktestPolicies(t *testing.T) {
+	start := time.Unix(1700000000, 0).UTC()
+	series := []PricePoint{
+		{Time: start, Denom: "BTC", Price: 100},
+		{Time: start.Add(time.Minute), Denom: "BTC", Price: 103},
+		{Time: start.Add(2 * time.Minute), Denom: "BTC", Price: 103},
+		{Time: start.Add(3 * time.Minute), Denom: "BTC", Price: 103},
+	}
+	params := BacktestParams{
+		Interval:  time.Hour,
+		Deviation: 0.05,
+		Assets: []config.Assets{{
+			Denom:     "BTC",
+			Policy:    config.Policy{Type: "deviation", Threshold: 0.02},
+			Smoothing: config.Smoothing{Confirmations: 2},
+		}},
+	}
+
+	got, err := Backtest(series, params, 0)
+	if err != nil {
+		t.Fatalf("Backtest() error = %v", err)
+	}
+	// the 3% move only relays on its second confirmation, at 2m
+	if btc := got.Assets[0]; btc.Relays != 2 || btc.DeviationRelays != 1 || btc.HeartbeatRelays != 0 {
+		t.Errorf("Backtest() asset = %+v, want an init and a deviation relay", btc)
+	}
+
+	params.Assets[0].Policy.Type = "unknown"
+	if _, err := Backtest(series, params, 0); err == nil {
+		t.Errorf("Backtest() expected error for an unknown policy")
+	}
+}
+
+func TestParsePriceSeries(t *testing.T) {
+	csvInput := "timestamp,denom,price\n1700000000,BTC,100.5\n2023-11-14T22:14:00Z,ETH,10\n"
+	points, err := parsePriceSeriesCSV(strings.NewReader(csvInput))
+	if err != nil {
+		t.Fatalf("parsePriceSeriesCSV() error = %v", err)
+	}
+	if len(points) != 2 || points[0].Denom != "BTC" || points[0].Price != 100.5 ||
+		!points[0].Time.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("parsePriceSeriesCSV() = %+v", points)
+	}
+
+	jsonInput := `[{"timestamp": 1700000000, "denom": "BTC", "price": 100.5},
+		{"timestamp": "2023-11-14T22:14:00Z", "denom": "ETH", "price": "10"}]`
+	points, err = parsePriceSeriesJSON(strings.NewReader(jsonInput))
+	if err != nil {
+		t.Fatalf("parsePriceSeriesJSON() error = %v", err)
+	}
+	if len(points) != 2 || points[1].Denom != "ETH" || points[1].Price != 10 ||
+		!points[1].Time.Equal(time.Date(2023, 11, 14, 22, 14, 0, 0, time.UTC)) {
+		t.Errorf("parsePriceSeriesJSON() = %+v", points)
+	}
+
+	if _, err := parsePriceSeriesCSV(strings.NewReader("time,denom,price\n")); err == nil {
+		t.Errorf("parsePriceSeriesCSV() expected error for missing timestamp column")
+	}
+}
@@ -0,0 +1,64 @@
+package relayer
+
+import (
+	"slices"
+	"time"
+)
+
+// batchWindow collects the denoms triggered within a short window, so that
+// correlated assets crossing their thresholds a few seconds apart are relayed
+// together.
+type batchWindow struct {
+	denoms []string
+	opened time.Time
+	height int64
+}
+
+// batchingEnabled returns true if a batching window is configured.
+func (r *Relayer) batchingEnabled() bool {
+	return r.cfg.Relayer.BatchWindow > 0 || r.cfg.Relayer.BatchWindowBlocks > 0
+}
+
+// collect adds the triggered denoms to the batching window, opening it if
+// needed, and returns the denoms to relay now: every collected denom once the
+// window closed or an urgent denom triggered, or none while it is open.
+// Without batching window, the triggered denoms are relayed right away.
+func (r *Relayer) collect(triggered []string, urgent bool, now time.Time, height int64) []string {
+	if !r.batchingEnabled() {
+		return triggered
+	}
+
+	w := &r.window
+	for _, denom := range triggered {
+		if !slices.Contains(w.denoms, denom) {
+			w.denoms = append(w.denoms, denom)
+		}
+	}
+	if len(w.denoms) == 0 {
+		return nil
+	}
+	if w.opened.IsZero() {
+		w.opened, w.height = now, height
+		r.logger.Info().Strs("denoms", w.denoms).Msg("batching window opened")
+	}
+
+	expired := (r.cfg.Relayer.BatchWindow > 0 && now.Sub(w.opened) >= r.cfg.Relayer.BatchWindow) ||
+		(r.cfg.Relayer.BatchWindowBlocks > 0 && height-w.height >= r.cfg.Relayer.BatchWindowBlocks)
+	if !expired && !urgent {
+		return nil
+	}
+
+	// assets may have been halted, removed or become unreadable while in
+	// the window
+	batch := make([]string, 0, len(w.denoms))
+	for _, denom := range w.denoms {
+		if r.assetIndex(denom) >= 0 && !r.breaker.isHalted(denom) && !r.failing(denom) {
+			batch = append(batch, denom)
+		}
+	}
+	if urgent {
+		r.logger.Info().Strs("denoms", batch).Msg("batching window closed early by an urgent deviation")
+	}
+	r.window = batchWindow{}
+	return batch
+}
@@ -0,0 +1,43 @@
+package relayer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/rs/zerolog"
+)
+
+func TestCollect(t *testing.T) {
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, config.Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r.cfg.Relayer.BatchWindow = 5 * time.Second
+	r.latestAssets = []asset{{denom: "ETH"}, {denom: "STETH"}, {denom: "EZETH"}}
+
+	now := time.Now()
+	if got := r.collect([]string{"ETH"}, false, now, 0); len(got) != 0 {
+		t.Errorf("collect() on first trigger = %v, want none", got)
+	}
+	if got := r.collect([]string{"ETH", "STETH"}, false, now.Add(2*time.Second), 0); len(got) != 0 {
+		t.Errorf("collect() within window = %v, want none", got)
+	}
+	got := r.collect([]string{}, false, now.Add(5*time.Second), 0)
+	if want := []string{"ETH", "STETH"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("collect() after window = %v, want %v", got, want)
+	}
+
+	// urgent deviations close the window right away
+	got = r.collect([]string{"EZETH"}, true, now.Add(6*time.Second), 0)
+	if want := []string{"EZETH"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("collect() urgent = %v, want %v", got, want)
+	}
+
+	r.cfg.Relayer.BatchWindow = 0
+	if got := r.collect([]string{"ETH"}, false, now, 0); len(got) != 1 {
+		t.Errorf("collect() without window = %v, want ETH", got)
+	}
+}
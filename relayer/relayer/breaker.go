@@ -0,0 +1,215 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+)
+
+// ErrNotHalted is returned when clearing a denom that is not halted.
+var ErrNotHalted = errors.New("denom is not halted")
+
+// Halt defines a denom whose relays are blocked by the circuit breaker.
+type Halt struct {
+	Denom  string    `json:"denom"`
+	Reason string    `json:"reason"`
+	Price  float64   `json:"price"`
+	Time   time.Time `json:"time"`
+}
+
+// breaker keeps track of the halted denoms. It is safe for concurrent use,
+// as halts are cleared through the admin API.
+type breaker struct {
+	mtx    sync.Mutex
+	halted map[string]Halt
+}
+
+func newBreaker() *breaker {
+	return &breaker{halted: map[string]Halt{}}
+}
+
+// halt halts a denom and returns false if it was already halted.
+func (b *breaker) halt(h Halt) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if _, ok := b.halted[h.Denom]; ok {
+		return false
+	}
+	b.halted[h.Denom] = h
+	return true
+}
+
+func (b *breaker) isHalted(denom string) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	_, ok := b.halted[denom]
+	return ok
+}
+
+// clear resumes the relays of a denom and returns false if it was not halted.
+func (b *breaker) clear(denom string) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if _, ok := b.halted[denom]; !ok {
+		return false
+	}
+	delete(b.halted, denom)
+	return true
+}
+
+// halts returns the halted denoms sorted by denom.
+func (b *breaker) halts() []Halt {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	halts := make([]Halt, 0, len(b.halted))
+	for _, h := range b.halted {
+		halts = append(halts, h)
+	}
+	sort.Slice(halts, func(i, j int) bool { return halts[i].Denom < halts[j].Denom })
+	return halts
+}
+
+// Halts returns the denoms halted by the circuit breaker.
+func (r *Relayer) Halts() []Halt {
+	return r.breaker.halts()
+}
+
+// ClearHalt resumes the relays of a denom halted by the circuit breaker.
+func (r *Relayer) ClearHalt(denom string) error {
+	if !r.breaker.clear(denom) {
+		return ErrNotHalted
+	}
+	r.logger.Info().Str("denom", denom).Msg("halt cleared")
+	telemetry.SetGauge(float32(len(r.breaker.halts())), "breaker", "halted")
+	return nil
+}
+
+// halt blocks the relays of a denom until its halt is cleared and raises an alert.
+func (r *Relayer) halt(denom string, price float64, reason string) {
+	if i := r.assetIndex(denom); i >= 0 {
+		// the next price after the halt is cleared is not compared to this one
+		r.latestAssets[i].lastObserved = 0
+	}
+
+	if !r.breaker.halt(Halt{Denom: denom, Reason: reason, Price: price, Time: time.Now()}) {
+		return
+	}
+	telemetry.SetGauge(float32(len(r.breaker.halts())), "breaker", "halted")
+	r.alert(Alert{
+		Kind:    "halt",
+		Denom:   denom,
+		Message: fmt.Sprintf("relays halted by the circuit breaker: %s", reason),
+	})
+}
+
+// checkPrice returns why a price fails the sanity bounds of its asset, or an
+// empty string if it passes them. lastPrice is the previously observed price
+// of the asset, or zero.
+func checkPrice(bounds config.Assets, lastPrice, price float64) string {
+	switch {
+	case price <= 0:
+		return fmt.Sprintf("price %v is not positive", price)
+	case bounds.MinPrice > 0 && price < bounds.MinPrice:
+		return fmt.Sprintf("price %v is below min_price %v", price, bounds.MinPrice)
+	case bounds.MaxPrice > 0 && price > bounds.MaxPrice:
+		return fmt.Sprintf("price %v is above max_price %v", price, bounds.MaxPrice)
+	}
+
+	if bounds.MaxStep > 0 && lastPrice > 0 {
+		if step := policy.Change(lastPrice, price); step > bounds.MaxStep {
+			return fmt.Sprintf("price moved by %v from %v to %v, above max_step %v", step, lastPrice, price, bounds.MaxStep)
+		}
+	}
+	return ""
+}
+
+// stale returns true if the latest median, stamped at medianBlock, is more
+// than maxPeriods median stamp periods old as of height. A zero maxPeriods
+// disables the check.
+func stale(medianBlock, height, period, maxPeriods uint64) bool {
+	if maxPeriods == 0 || period == 0 || height <= medianBlock {
+		return false
+	}
+	return height-medianBlock > period*maxPeriods
+}
+
+// assetBounds returns the config of the given denom.
+func (r *Relayer) assetBounds(denom string) config.Assets {
+	for _, a := range r.cfg.Assets {
+		if a.Denom == denom {
+			return a
+		}
+	}
+	return config.Assets{Denom: denom}
+}
+
+// dropStale halts the denoms of the batch whose oracle medians are stale and
+// returns the remaining denoms. Denoms whose staleness cannot be checked are
+// dropped and recorded as failed price reads.
+func (r *Relayer) dropStale(ctx context.Context, batch []string) []string {
+	maxPeriods := r.cfg.CircuitBreaker.MaxStalePeriods
+	if maxPeriods == 0 || len(batch) == 0 {
+		return batch
+	}
+
+	params, err := r.relayerClient.GetOracleParams(ctx)
+	if err == nil {
+		var h int64
+		h, err = r.relayerClient.ChainHeight.GetChainHeight()
+		if err == nil {
+			return r.dropStaleAt(ctx, batch, params.MedianStampPeriod, uint64(h))
+		}
+	}
+	// no median can be checked
+	for _, denom := range batch {
+		r.staleCheckFailed(denom, err)
+	}
+	return []string{}
+}
+
+// dropStaleAt halts the denoms of the batch whose oracle medians are stale as
+// of the given height and returns the remaining denoms.
+func (r *Relayer) dropStaleAt(ctx context.Context, batch []string, medianStampPeriod, height uint64) []string {
+	maxPeriods := r.cfg.CircuitBreaker.MaxStalePeriods
+	fresh := make([]string, 0, len(batch))
+	for _, denom := range batch {
+		median, err := r.relayerClient.GetLatestMedian(ctx, denom)
+		if err != nil {
+			r.staleCheckFailed(denom, err)
+			continue
+		}
+		if stale(median.BlockNum, height, medianStampPeriod, maxPeriods) {
+			price := 0.0
+			if i := r.assetIndex(denom); i >= 0 {
+				price = r.latestAssets[i].lastObserved
+			}
+			r.halt(denom, price, fmt.Sprintf("latest median was stamped at block %d, more than %d median stamp periods before block %d",
+				median.BlockNum, maxPeriods, height))
+			continue
+		}
+		fresh = append(fresh, denom)
+	}
+	return fresh
+}
+
+// staleCheckFailed records that the median of the given denom could not be
+// checked, which leaves it out of the relays until a price read succeeds.
+func (r *Relayer) staleCheckFailed(denom string, err error) {
+	err = fmt.Errorf("checking the staleness of the oracle median of %s: %w", denom, err)
+	if i := r.assetIndex(denom); i >= 0 {
+		r.priceFailed(i, err, time.Now())
+		return
+	}
+	r.logger.Err(err).Str("denom", denom).Msg("unable to check the staleness of the oracle price")
+}
@@ -0,0 +1,145 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	oracletypes "github.com/ojo-network/ojo/x/oracle/types"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+func TestCheckPrice(t *testing.T) {
+	bounds := config.Assets{Denom: "BTC", MinPrice: 1000, MaxPrice: 1000000, MaxStep: 0.2}
+
+	tests := []struct {
+		name      string
+		lastPrice float64
+		price     float64
+		wantOK    bool
+	}{
+		{name: "within bounds", lastPrice: 60000, price: 62000, wantOK: true},
+		{name: "first price", lastPrice: 0, price: 62000, wantOK: true},
+		{name: "zero price", lastPrice: 60000, price: 0},
+		{name: "below min", lastPrice: 0, price: 999},
+		{name: "above max", lastPrice: 0, price: 1000001},
+		{name: "step too large", lastPrice: 60000, price: 80000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := checkPrice(bounds, tt.lastPrice, tt.price)
+			if (reason == "") != tt.wantOK {
+				t.Errorf("checkPrice() = %q, want ok %v", reason, tt.wantOK)
+			}
+		})
+	}
+
+	// without bounds, only non positive prices are rejected
+	if reason := checkPrice(config.Assets{Denom: "BTC"}, 1, 1000); reason != "" {
+		t.Errorf("checkPrice() without bounds = %q, want ok", reason)
+	}
+}
+
+func TestStale(t *testing.T) {
+	tests := []struct {
+		name        string
+		medianBlock uint64
+		height      uint64
+		maxPeriods  uint64
+		want        bool
+	}{
+		{name: "fresh", medianBlock: 900, height: 1000, maxPeriods: 3},
+		{name: "at the limit", medianBlock: 700, height: 1000, maxPeriods: 3},
+		{name: "stale", medianBlock: 699, height: 1000, maxPeriods: 3, want: true},
+		{name: "disabled", medianBlock: 0, height: 1000, maxPeriods: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stale(tt.medianBlock, tt.height, 100, tt.maxPeriods); got != tt.want {
+				t.Errorf("stale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHalt(t *testing.T) {
+	cfg := config.Config{Assets: []config.Assets{{Denom: "BTC"}}}
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r.latestAssets = []asset{{denom: "BTC", lastObserved: 60000}}
+
+	r.halt("BTC", 0, "price 0 is not positive")
+	if !r.breaker.isHalted("BTC") || len(r.Halts()) != 1 {
+		t.Fatalf("halt() did not halt BTC")
+	}
+	if r.latestAssets[0].lastObserved != 0 {
+		t.Errorf("halt() kept the last observed price")
+	}
+	if status := r.Status(); len(status.Halts) != 1 {
+		t.Errorf("Status() halts = %v, want BTC", status.Halts)
+	}
+
+	if err := r.ClearHalt("BTC"); err != nil {
+		t.Errorf("ClearHalt() error = %v", err)
+	}
+	if err := r.ClearHalt("BTC"); err != ErrNotHalted {
+		t.Errorf("ClearHalt() error = %v, want %v", err, ErrNotHalted)
+	}
+}
+
+// medianServer serves the medians of the oracle module, failing for the
+// denoms without one.
+type medianServer struct {
+	oracletypes.UnimplementedQueryServer
+	medians map[string]uint64
+}
+
+func (s *medianServer) Medians(_ context.Context, req *oracletypes.QueryMedians) (*oracletypes.QueryMediansResponse, error) {
+	block, ok := s.medians[req.Denom]
+	if !ok {
+		return nil, errors.New("median unavailable")
+	}
+	return &oracletypes.QueryMediansResponse{Medians: []oracletypes.PriceStamp{{BlockNum: block}}}, nil
+}
+
+func TestDropStaleFailures(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	server := grpc.NewServer()
+	oracletypes.RegisterQueryServer(server, &medianServer{medians: map[string]uint64{"BTC": 990, "ATOM": 100}})
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	cfg := config.Config{
+		Assets:         []config.Assets{{Denom: "BTC"}, {Denom: "ETH"}, {Denom: "ATOM"}},
+		CircuitBreaker: config.CircuitBreaker{MaxStalePeriods: 3},
+	}
+	r, err := New(zerolog.Nop(), client.RelayerClient{GRPCEndpoint: lis.Addr().String(), RPCTimeout: time.Second}, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r.latestAssets = []asset{{denom: "BTC"}, {denom: "ETH"}, {denom: "ATOM"}}
+
+	// the median of ETH cannot be read, ATOM is stale: only BTC is relayed
+	got := r.dropStaleAt(context.Background(), []string{"BTC", "ETH", "ATOM"}, 10, 1000)
+	if len(got) != 1 || got[0] != "BTC" {
+		t.Errorf("dropStaleAt() = %v, want BTC", got)
+	}
+	if eth := r.latestAssets[1]; eth.failures != 1 || eth.lastError == nil {
+		t.Errorf("dropStaleAt() ETH failures = %d, want a failed read", eth.failures)
+	}
+	if !r.breaker.isHalted("ATOM") || r.breaker.isHalted("ETH") {
+		t.Errorf("dropStaleAt() halts = %v, want ATOM", r.Halts())
+	}
+}
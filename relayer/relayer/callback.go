@@ -0,0 +1,62 @@
+package relayer
+
+import (
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/evm"
+)
+
+// noCallbackContract is the placeholder client contract address of relays
+// without callback, sent with an empty command selector and params.
+const noCallbackContract = "0x001"
+
+// callbackGroup defines denoms relayed in a single message, calling back the
+// same contract.
+type callbackGroup struct {
+	callback config.Callback
+	denoms   []string
+}
+
+// callbackOf returns the callback of the given denom, or the callback of the
+// relayer section if the asset has none.
+func (r *Relayer) callbackOf(denom string) config.Callback {
+	for _, a := range r.cfg.Assets {
+		if a.Denom == denom && a.Callback.Enabled() {
+			return a.Callback
+		}
+	}
+	return r.cfg.Relayer.Callback
+}
+
+// groupByCallback groups the denoms by callback, in the order of their first
+// denom.
+func (r *Relayer) groupByCallback(denoms []string) []callbackGroup {
+	groups := []callbackGroup{}
+	for _, denom := range denoms {
+		cb := r.callbackOf(denom)
+		found := false
+		for i := range groups {
+			if groups[i].callback == cb {
+				groups[i].denoms = append(groups[i].denoms, denom)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, callbackGroup{callback: cb, denoms: []string{denom}})
+		}
+	}
+	return groups
+}
+
+// callbackArgs returns the client contract address, command selector and
+// command params of a relay calling back the given callback.
+func callbackArgs(cb config.Callback) (string, []byte, []byte, error) {
+	if !cb.Enabled() {
+		return noCallbackContract, []byte{}, []byte{}, nil
+	}
+	params, err := cb.ParamsBytes()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return cb.Contract, evm.Selector(cb.Signature()), params, nil
+}
@@ -0,0 +1,59 @@
+package relayer
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/evm"
+	"github.com/rs/zerolog"
+)
+
+func TestGroupByCallback(t *testing.T) {
+	rebalance := config.Callback{
+		Contract: "0x5BB3E85f91D08fe92a3D123EE35050b763D6E6A7",
+		Function: "rebalance(bytes32[], bytes)",
+		Params:   "0x01",
+	}
+	liquidate := config.Callback{
+		Contract: "0x5BB3E85f91D08fe92a3D123EE35050b763D6E6A7",
+		Function: "checkLiquidations(bytes32[],bytes)",
+	}
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, config.Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r.cfg = config.Config{
+		Relayer: config.Relayer{Callback: rebalance},
+		Assets: []config.Assets{
+			{Denom: "BTC"},
+			{Denom: "ETH", Callback: liquidate},
+			{Denom: "ATOM"},
+		},
+	}
+
+	groups := r.groupByCallback([]string{"BTC", "ETH", "ATOM"})
+	want := []callbackGroup{
+		{callback: rebalance, denoms: []string{"BTC", "ATOM"}},
+		{callback: liquidate, denoms: []string{"ETH"}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Fatalf("groupByCallback() = %v, want %v", groups, want)
+	}
+
+	contract, selector, params, err := callbackArgs(rebalance)
+	if err != nil {
+		t.Fatalf("callbackArgs() error = %v", err)
+	}
+	wantSelector := evm.Selector("rebalance(bytes32[],bytes)")
+	if contract != rebalance.Contract || !bytes.Equal(selector, wantSelector) || !bytes.Equal(params, []byte{1}) {
+		t.Errorf("callbackArgs() = %s, %x, %x", contract, selector, params)
+	}
+
+	contract, selector, _, err = callbackArgs(config.Callback{})
+	if err != nil || contract != noCallbackContract || len(selector) != 0 {
+		t.Errorf("callbackArgs() without callback = %s, %x, %v", contract, selector, err)
+	}
+}
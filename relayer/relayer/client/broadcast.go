@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/hashicorp/go-metrics"
+)
+
+// ErrInsufficientFunds is returned when the relayer account cannot pay for a
+// relay tx.
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+const (
+	// outOfGasAdjustment is the gas adjustment of the simulation of txs
+	// which ran out of gas, and the factor raising the adjustment of the
+	// simulated ones.
+	outOfGasAdjustment = 1.5
+	// maxGasAdjustment caps the gas adjustment raised on out of gas txs.
+	maxGasAdjustment = 4.0
+)
+
+// expectedSequence matches the sequence the node expects in sequence
+// mismatch errors, e.g. "account sequence mismatch, expected 5, got 4".
+var expectedSequence = regexp.MustCompile(`expected (\d+), got \d+`)
+
+// txFailure defines the cause of a failed broadcast, which is remediated
+// differently.
+type txFailure string
+
+const (
+	txFailureNone     txFailure = ""
+	txFailureSequence txFailure = "sequence"
+	txFailureFee      txFailure = "fee"
+	txFailureOutOfGas txFailure = "out_of_gas"
+	txFailureFunds    txFailure = "funds"
+	txFailureMempool  txFailure = "in_mempool"
+	txFailureOther    txFailure = "other"
+)
+
+// txFailures are the SDK errors of the remediated failures.
+var txFailures = []struct {
+	err     *errorsmod.Error
+	failure txFailure
+}{
+	{err: sdkerrors.ErrWrongSequence, failure: txFailureSequence},
+	{err: sdkerrors.ErrInsufficientFee, failure: txFailureFee},
+	{err: sdkerrors.ErrOutOfGas, failure: txFailureOutOfGas},
+	{err: sdkerrors.ErrInsufficientFunds, failure: txFailureFunds},
+	{err: sdkerrors.ErrTxInMempoolCache, failure: txFailureMempool},
+}
+
+// classifyTx returns the cause of a failed broadcast from the code of its
+// response or, if the tx was not broadcasted, e.g. because its simulation
+// failed, from its error.
+func classifyTx(resp *sdk.TxResponse, err error) txFailure {
+	if resp != nil && resp.Code != 0 {
+		for _, f := range txFailures {
+			if resp.Codespace == f.err.Codespace() && resp.Code == f.err.ABCICode() {
+				return f.failure
+			}
+		}
+		return txFailureOther
+	}
+	if err == nil {
+		return txFailureNone
+	}
+	for _, f := range txFailures {
+		if strings.Contains(err.Error(), f.err.Error()) {
+			return f.failure
+		}
+	}
+	return txFailureOther
+}
+
+// resyncSequence returns the factory with the sequence the node expects, as
+// of the given sequence mismatch error, or with a zero sequence, which is
+// queried again, if it is not found.
+func resyncSequence(txf tx.Factory, err error) tx.Factory {
+	if m := expectedSequence.FindStringSubmatch(err.Error()); m != nil {
+		if seq, err := strconv.ParseUint(m[1], 10, 64); err == nil {
+			return txf.WithSequence(seq)
+		}
+	}
+	return txf.WithSequence(0)
+}
+
+// raiseGasAdjustment returns the gas adjustment of a tx which ran out of gas
+// with the given adjustment, zero if its gas was not simulated, up to
+// maxGasAdjustment. It returns false if the adjustment cannot be raised.
+func raiseGasAdjustment(adjustment float64) (float64, bool) {
+	if adjustment <= 0 {
+		return outOfGasAdjustment, true
+	}
+	if adjustment >= maxGasAdjustment {
+		return adjustment, false
+	}
+	return min(adjustment*outOfGasAdjustment, maxGasAdjustment), true
+}
+
+// bumpGasPrices returns the given gas prices multiplied by the bump, each up
+// to its max price. It returns false if no price can be bumped.
+func bumpGasPrices(prices, maxPrices sdk.DecCoins, bump float64) (sdk.DecCoins, bool) {
+	bumped := sdk.DecCoins{}
+	changed := false
+	for _, p := range prices {
+		amount := math.LegacyMinDec(p.Amount.Mul(decOf(bump)), maxPrices.AmountOf(p.Denom))
+		if amount.GT(p.Amount) {
+			changed = true
+		} else {
+			amount = p.Amount
+		}
+		bumped = bumped.Add(sdk.NewDecCoinFromDec(p.Denom, amount))
+	}
+	return bumped, changed
+}
+
+// decOf returns the given factor as a decimal, or zero if it is invalid.
+func decOf(f float64) math.LegacyDec {
+	d, err := math.LegacyNewDecFromStr(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return math.LegacyZeroDec()
+	}
+	return d
+}
+
+// escalateGasPrices returns the gas prices of a tx first priced at base and
+// currently at the given prices, after the given number of insufficient fee
+// rejections: the base prices times the step of the ladder of the gas
+// strategy, or else the current prices times the gas price bump, up to the
+// max gas prices. It returns false if the prices cannot be raised further.
+func (rc RelayerClient) escalateGasPrices(base, current sdk.DecCoins, rejections int) (sdk.DecCoins, bool) {
+	maxPrices, _ := sdk.ParseDecCoins(rc.MaxGasPrices)
+	ladder := rc.GasStrategy.Ladder
+	if len(ladder) == 0 {
+		return bumpGasPrices(current, maxPrices, rc.GasPriceBump)
+	}
+	if rejections > len(ladder) {
+		return current, false
+	}
+	prices, _ := bumpGasPrices(base, maxPrices, ladder[rejections-1])
+	for _, p := range prices {
+		if p.Amount.GT(current.AmountOf(p.Denom)) {
+			return prices, true
+		}
+	}
+	return current, false
+}
+
+// nodeGasPrices returns the minimum gas prices of the Ojo node, in the denoms
+// of the configured gas prices, times the multiplier of the gas strategy, up
+// to the max gas prices. It falls back to the configured gas prices if the
+// node has none in their denoms or cannot be queried.
+func (rc RelayerClient) nodeGasPrices(ctx context.Context) sdk.DecCoins {
+	fallback, _ := sdk.ParseDecCoins(rc.GasPrices)
+	minPrices, err := QueryMinGasPrices(ctx, rc.GRPCEndpoint, rc.RPCTimeout)
+	if err != nil {
+		rc.Logger.Err(err).Msg("unable to query the minimum gas prices of the node; using gas_prices")
+		return fallback
+	}
+
+	// the max gas prices cap the node prices, even below their minimum
+	maxPrices, _ := sdk.ParseDecCoins(rc.MaxGasPrices)
+	prices := sdk.DecCoins{}
+	for _, p := range minPrices {
+		if fallback.AmountOf(p.Denom).IsZero() {
+			continue
+		}
+		amount := p.Amount.Mul(decOf(rc.GasStrategy.Multiplier))
+		if maxPrice := maxPrices.AmountOf(p.Denom); maxPrice.IsPositive() && amount.GT(maxPrice) {
+			rc.Logger.Warn().
+				Str("min_gas_price", p.String()).
+				Str("max_gas_prices", rc.MaxGasPrices).
+				Msg("gas price of the node capped by max_gas_prices")
+			amount = maxPrice
+		}
+		prices = prices.Add(sdk.NewDecCoinFromDec(p.Denom, amount))
+
+		price, _ := amount.Float64()
+		telemetry.SetGaugeWithLabels([]string{"gas", "price"}, float32(price), []metrics.Label{
+			telemetry.NewLabel("denom", p.Denom),
+		})
+	}
+	if prices.Empty() {
+		return fallback
+	}
+	return prices
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+func TestClassifyTx(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *sdk.TxResponse
+		err  error
+		want txFailure
+	}{
+		{name: "success", resp: &sdk.TxResponse{TxHash: "ABC"}, want: txFailureNone},
+		{
+			name: "sequence mismatch",
+			resp: &sdk.TxResponse{Codespace: "sdk", Code: 32, RawLog: "account sequence mismatch, expected 5, got 4"},
+			want: txFailureSequence,
+		},
+		{name: "insufficient fee", resp: &sdk.TxResponse{Codespace: "sdk", Code: 13}, want: txFailureFee},
+		{name: "out of gas", resp: &sdk.TxResponse{Codespace: "sdk", Code: 11}, want: txFailureOutOfGas},
+		{name: "in mempool", resp: &sdk.TxResponse{Codespace: "sdk", Code: 19, TxHash: "ABC"}, want: txFailureMempool},
+		{name: "other module", resp: &sdk.TxResponse{Codespace: "gmp", Code: 5}, want: txFailureOther},
+		{
+			name: "simulation without funds",
+			err:  sdkerrors.ErrInsufficientFunds.Wrap("spendable balance 0uaxl is smaller than 1000uaxl"),
+			want: txFailureFunds,
+		},
+		{name: "unknown error", err: errors.New("connection refused"), want: txFailureOther},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := classifyTx(tc.resp, tc.err); got != tc.want {
+				t.Errorf("classifyTx() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResyncSequence(t *testing.T) {
+	txf := tx.Factory{}.WithSequence(4)
+	err := errors.New("account sequence mismatch, expected 7, got 4: incorrect account sequence")
+	if got := resyncSequence(txf, err).Sequence(); got != 7 {
+		t.Errorf("resyncSequence() = %d, want 7", got)
+	}
+	if got := resyncSequence(txf, errors.New("incorrect account sequence")).Sequence(); got != 0 {
+		t.Errorf("resyncSequence() without expected sequence = %d, want 0", got)
+	}
+}
+
+func TestRaiseGasAdjustment(t *testing.T) {
+	tests := []struct {
+		adjustment float64
+		want       float64
+		wantOK     bool
+	}{
+		{adjustment: 0, want: outOfGasAdjustment, wantOK: true},
+		{adjustment: 2, want: 3, wantOK: true},
+		{adjustment: 3, want: maxGasAdjustment, wantOK: true},
+		{adjustment: maxGasAdjustment, want: maxGasAdjustment, wantOK: false},
+	}
+	for _, tc := range tests {
+		got, ok := raiseGasAdjustment(tc.adjustment)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("raiseGasAdjustment(%v) = %v, %v, want %v, %v", tc.adjustment, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestBumpGasPrices(t *testing.T) {
+	prices := sdk.NewDecCoins(sdk.NewDecCoinFromDec("uojo", math.LegacyMustNewDecFromStr("0.02")))
+	maxPrices := sdk.NewDecCoins(sdk.NewDecCoinFromDec("uojo", math.LegacyMustNewDecFromStr("0.05")))
+
+	bumped, ok := bumpGasPrices(prices, maxPrices, 1.5)
+	if !ok || !bumped.AmountOf("uojo").Equal(math.LegacyMustNewDecFromStr("0.03")) {
+		t.Errorf("bumpGasPrices() = %s, %v, want 0.03uojo", bumped, ok)
+	}
+	bumped, ok = bumpGasPrices(bumped, maxPrices, 2)
+	if !ok || !bumped.AmountOf("uojo").Equal(math.LegacyMustNewDecFromStr("0.05")) {
+		t.Errorf("bumpGasPrices() over max = %s, %v, want 0.05uojo", bumped, ok)
+	}
+	if _, ok := bumpGasPrices(bumped, maxPrices, 2); ok {
+		t.Error("bumpGasPrices() at max = true, want false")
+	}
+	if _, ok := bumpGasPrices(prices, sdk.DecCoins{}, 2); ok {
+		t.Error("bumpGasPrices() without max = true, want false")
+	}
+}
+
+func TestEscalateGasPrices(t *testing.T) {
+	base := sdk.NewDecCoins(sdk.NewDecCoinFromDec("uojo", math.LegacyMustNewDecFromStr("0.02")))
+	rc := RelayerClient{
+		MaxGasPrices: "0.05uojo",
+		GasStrategy:  config.GasStrategy{Ladder: []float64{1.5, 2, 3}},
+	}
+
+	want := []string{"0.03", "0.04", "0.05"}
+	current := base
+	for i, w := range want {
+		prices, ok := rc.escalateGasPrices(base, current, i+1)
+		if !ok || !prices.AmountOf("uojo").Equal(math.LegacyMustNewDecFromStr(w)) {
+			t.Fatalf("escalateGasPrices() step %d = %s, %v, want %suojo", i+1, prices, ok, w)
+		}
+		current = prices
+	}
+	if _, ok := rc.escalateGasPrices(base, current, len(want)+1); ok {
+		t.Error("escalateGasPrices() past the ladder = true, want false")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	tmrpcclient "github.com/cometbft/cometbft/rpc/client"
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
@@ -14,6 +15,8 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const unsubscribeTimeout = 5 * time.Second
+
 var (
 	errParseEventDataNewBlockHeader = errors.New("error parsing EventDataNewBlockHeader")
 	queryEventNewBlockHeader        = tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader)
@@ -29,10 +32,13 @@ type ChainHeight struct {
 	mtx               sync.RWMutex
 	errGetChainHeight error
 	lastChainHeight   int64
+	cancel            context.CancelFunc
+	done              chan struct{}
 }
 
 // NewChainHeight returns a new ChainHeight struct that
-// starts a new goroutine subscribed to EventNewBlockHeader.
+// starts a new goroutine subscribed to EventNewBlockHeader,
+// until the context is done or Close is called.
 func NewChainHeight(
 	ctx context.Context,
 	client client.CometRPC,
@@ -57,13 +63,22 @@ func NewChainHeight(
 		return nil, err
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
 	chainHeight := &ChainHeight{
 		Logger:            logger.With().Str("relayer_client", "chain_height").Logger(),
 		errGetChainHeight: nil,
 		lastChainHeight:   initialHeight,
+		cancel:            cancel,
+		done:              make(chan struct{}),
 	}
 
-	go chainHeight.subscribe(ctx, rpcClient, newBlockHeaderSubscription)
+	go func() {
+		defer close(chainHeight.done)
+		chainHeight.subscribe(ctx, rpcClient, newBlockHeaderSubscription)
+		if err := rpcClient.Stop(); err != nil {
+			chainHeight.Logger.Err(err).Msg("unable to stop the rpc client")
+		}
+	}()
 
 	return chainHeight, nil
 }
@@ -87,9 +102,12 @@ func (chainHeight *ChainHeight) subscribe(
 	for {
 		select {
 		case <-ctx.Done():
-			err := eventsClient.Unsubscribe(ctx, tmtypes.EventNewBlockHeader, queryEventNewBlockHeader.String())
+			// ctx is done, unsubscribe with a context of its own
+			unsubscribeCtx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+			err := eventsClient.Unsubscribe(unsubscribeCtx, tmtypes.EventNewBlockHeader, queryEventNewBlockHeader.String())
+			cancel()
 			if err != nil {
-				chainHeight.Logger.Err(err)
+				chainHeight.Logger.Err(err).Msg("unable to unsubscribe from new block headers")
 				chainHeight.updateChainHeight(chainHeight.lastChainHeight, err)
 			}
 			chainHeight.Logger.Info().Msg("closing the ChainHeight subscription")
@@ -107,6 +125,16 @@ func (chainHeight *ChainHeight) subscribe(
 	}
 }
 
+// Close closes the subscription. Done is closed once it is unsubscribed.
+func (chainHeight *ChainHeight) Close() {
+	chainHeight.cancel()
+}
+
+// Done returns a channel that is closed once the subscription is closed.
+func (chainHeight *ChainHeight) Done() <-chan struct{} {
+	return chainHeight.done
+}
+
 // GetChainHeight returns the last chain height available.
 func (chainHeight *ChainHeight) GetChainHeight() (int64, error) {
 	chainHeight.mtx.RLock()
@@ -3,7 +3,6 @@ package client
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -20,12 +19,15 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/hashicorp/go-metrics"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/retry"
 	ojoparams "github.com/ojo-network/ojo/app/params"
+	gmptypes "github.com/ojo-network/ojo/x/gmp/types"
 	oracletypes "github.com/ojo-network/ojo/x/oracle/types"
 	"github.com/rs/zerolog"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type (
@@ -46,6 +48,10 @@ type (
 		GRPCEndpoint      string
 		KeyringPassphrase string
 		ChainHeight       *ChainHeight
+		Retry             config.Retries
+		MaxGasPrices      string
+		GasPriceBump      float64
+		GasStrategy       config.GasStrategy
 	}
 
 	passReader struct {
@@ -197,7 +203,7 @@ func (oc RelayerClient) CreateTxFactory() (tx.Factory, error) {
 		return tx.Factory{}, err
 	}
 
-	return tx.Factory{}.
+	factory := tx.Factory{}.
 		WithAccountRetriever(clientCtx.AccountRetriever).
 		WithChainID(oc.ChainID).
 		WithTxConfig(clientCtx.TxConfig).
@@ -205,21 +211,23 @@ func (oc RelayerClient) CreateTxFactory() (tx.Factory, error) {
 		WithGasPrices(oc.GasPrices).
 		WithKeybase(clientCtx.Keyring).
 		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
-		WithSimulateAndExecute(true), nil
+		WithSimulateAndExecute(true)
+	// the gas is only simulated with a gas adjustment
+	if oc.GasStrategy.Mode == config.GasModeSimulate {
+		factory = factory.WithGasAdjustment(oc.GasStrategy.GasAdjustment)
+	}
+	return factory, nil
 }
 
-// GetPrice gets the current price of an asset from the Ojo node.
+// GetPrice gets the current price of an asset from the Ojo node. It fails
+// with ErrPriceNotFound if the node has no price for the asset, and with
+// ErrNodeUnavailable if the node does not answer.
 func (r *RelayerClient) GetPrice(ctx context.Context, denom string) (sdk.DecCoin, error) {
-	grpcConn, err := grpc.Dial(
-		r.GRPCEndpoint,
-		// the Cosmos SDK doesn't support any transport security mechanism
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithContextDialer(dialerFunc),
-	)
+	grpcConn, err := dialGRPC(r.GRPCEndpoint)
 	// retry or switch rpc
 	if err != nil {
 		r.Logger.Debug().Msg("error querying exchange rates")
-		return sdk.DecCoin{}, err
+		return sdk.DecCoin{}, fmt.Errorf("%w: %w", ErrNodeUnavailable, err)
 	}
 
 	defer grpcConn.Close()
@@ -232,83 +240,232 @@ func (r *RelayerClient) GetPrice(ctx context.Context, denom string) (sdk.DecCoin
 	queryResponse, err := queryClient.ExchangeRates(ctx, &oracletypes.QueryExchangeRates{
 		Denom: denom,
 	})
-
-	if err != nil || queryResponse.ExchangeRates.Empty() {
+	if err != nil {
 		r.Logger.Debug().Msg("error querying exchange rates")
-		return sdk.DecCoin{}, err
+		return sdk.DecCoin{}, priceError(denom, err)
+	}
+	if queryResponse.ExchangeRates.Empty() {
+		return sdk.DecCoin{}, fmt.Errorf("%w for %s", ErrPriceNotFound, denom)
 	}
 
 	return queryResponse.ExchangeRates[0], nil
 }
 
-// BroadcastTx attempts to broadcast a signed transaction. If it fails, a few re-attempts
-// will be made until the transaction succeeds or ultimately times out or fails.
-func (rc RelayerClient) BroadcastTx(nextBlockHeight, timeoutHeight int64, msgs ...sdk.Msg) error {
-	maxBlockHeight := nextBlockHeight + timeoutHeight
-	lastCheckHeight := nextBlockHeight - 1
-
+// BroadcastTx attempts to broadcast a signed transaction. If it fails, it is
+// broadcasted again as the broadcast retry policy defines, until the
+// transaction succeeds or ultimately fails or the context is done. The
+// response of the successful broadcast is returned.
+//
+// The gas and gas prices are set as the gas strategy defines. Failures are
+// remediated by their cause before broadcasting again: the sequence is
+// resynced on mismatches, the gas prices are raised up to MaxGasPrices on
+// insufficient fees and the gas is simulated, with a raised adjustment, once
+// out of gas.
+// A tx already in the mempool is tracked by its hash until it is included,
+// instead of broadcasted again, and insufficient funds fail with
+// ErrInsufficientFunds right away.
+func (rc RelayerClient) BroadcastTx(ctx context.Context, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
 	clientCtx, err := rc.CreateClientContext()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	factory, err := rc.CreateTxFactory()
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	// re-try voting until timeout
-	for lastCheckHeight < maxBlockHeight {
-		latestBlockHeight, err := rc.ChainHeight.GetChainHeight()
-		if err != nil {
-			return err
-		}
-
-		if latestBlockHeight <= lastCheckHeight {
-			continue
+	if rc.GasStrategy.Mode == config.GasModeNode {
+		factory = factory.WithGasPrices(rc.nodeGasPrices(ctx).String())
+	}
+	basePrices := factory.GasPrices()
+
+	var resp *sdk.TxResponse
+	attempt, rejections := 0, 0
+	err = retry.Do(ctx, "broadcast", rc.Retry.Broadcast, func(ctx context.Context) error {
+		attempt++
+		resp, err = BroadcastTx(clientCtx.WithCmdContext(ctx), factory, msgs...)
+		failure := classifyTx(resp, err)
+		if failure == txFailureNone {
+			return nil
 		}
 
-		// set last check height to latest block height
-		lastCheckHeight = latestBlockHeight
-
-		resp, err := BroadcastTx(clientCtx, factory, msgs...)
+		var (
+			code uint32
+			hash string
+		)
 		if resp != nil && resp.Code != 0 {
+			code, hash = resp.Code, resp.TxHash
 			telemetry.IncrCounter(1, "failure", "tx", "code")
 			err = fmt.Errorf("invalid response code from tx: %d. msg: %s",
 				resp.Code,
 				resp.RawLog,
 			)
 		}
-		if err != nil {
-			var (
-				code uint32
-				hash string
-			)
-			if resp != nil {
-				code = resp.Code
-				hash = resp.TxHash
+		telemetry.IncrCounterWithLabels([]string{"failure", "broadcast"}, 1, []metrics.Label{
+			telemetry.NewLabel("reason", string(failure)),
+		})
+		rc.Logger.Debug().
+			Err(err).
+			Int("attempt", attempt).
+			Str("tx_hash", hash).
+			Uint32("tx_code", code).
+			Str("reason", string(failure)).
+			Msg("failed to broadcast tx")
+
+		switch failure {
+		case txFailureSequence:
+			factory = resyncSequence(factory, err)
+			rc.Logger.Info().Uint64("sequence", factory.Sequence()).Msg("account sequence resynced")
+			return retry.Immediate(err)
+
+		case txFailureFee:
+			rejections++
+			prices, ok := rc.escalateGasPrices(basePrices, factory.GasPrices(), rejections)
+			if !ok {
+				// the same fee would be rejected again
+				return retry.Permanent(fmt.Errorf("%w; gas prices %s cannot be raised further", err, factory.GasPrices()))
 			}
+			factory = factory.WithGasPrices(prices.String())
+			telemetry.IncrCounter(1, "gas", "escalation")
+			rc.Logger.Info().Int("rejections", rejections).Str("gas_prices", prices.String()).Msg("gas prices raised")
+			return retry.Immediate(err)
+
+		case txFailureOutOfGas:
+			adjustment, ok := raiseGasAdjustment(factory.GasAdjustment())
+			if !ok {
+				// the same gas would run out again
+				return retry.Permanent(fmt.Errorf("%w; gas adjustment %v cannot be raised further", err, adjustment))
+			}
+			factory = factory.WithGasAdjustment(adjustment)
+			rc.Logger.Info().Float64("gas_adjustment", adjustment).Msg("simulating gas with a raised adjustment")
+			return retry.Immediate(err)
 
-			rc.Logger.Debug().
-				Err(err).
-				Int64("max_height", maxBlockHeight).
-				Int64("last_check_height", lastCheckHeight).
-				Str("tx_hash", hash).
-				Uint32("tx_code", code).
-				Msg("failed to broadcast tx; retrying...")
+		case txFailureFunds:
+			return retry.Permanent(fmt.Errorf("%w: %w", ErrInsufficientFunds, err))
 
-			time.Sleep(time.Second * 1)
-			continue
+		case txFailureMempool:
+			if hash == "" {
+				return err
+			}
+			// an earlier attempt reached the mempool
+			rc.Logger.Info().Str("tx_hash", hash).Msg("tx already in mempool; waiting for its inclusion")
+			included, waitErr := rc.WaitForTx(ctx, hash)
+			if waitErr != nil {
+				// the tx may have left the mempool, it is broadcasted again,
+				// unless it was included and failed
+				return fmt.Errorf("waiting for tx %s in mempool: %w", hash, waitErr)
+			}
+			resp = included
+			return nil
 		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("broadcasting tx after %d attempts: %w", attempt, err)
+	}
 
-		rc.Logger.Info().
-			Uint32("tx_code", resp.Code).
-			Str("tx_hash", resp.TxHash).
-			Int64("tx_height", resp.Height).
-			Msg("successfully broadcasted tx")
+	rc.Logger.Info().
+		Uint32("tx_code", resp.Code).
+		Str("tx_hash", resp.TxHash).
+		Int64("tx_height", resp.Height).
+		Msg("successfully broadcasted tx")
+
+	return resp, nil
+}
+
+// WaitForTx polls the Ojo node until the transaction with the given hash is
+// included in a block, as the confirmation retry policy defines, or the
+// context is done.
+func (rc RelayerClient) WaitForTx(ctx context.Context, txHash string) (*sdk.TxResponse, error) {
+	clientCtx, err := rc.CreateClientContext()
+	if err != nil {
+		return nil, err
+	}
 
+	var resp *sdk.TxResponse
+	err = retry.Do(ctx, "confirmation", rc.Retry.Confirmation, func(context.Context) error {
+		resp, err = authtx.QueryTx(clientCtx, txHash)
+		if err != nil {
+			rc.Logger.Debug().Err(err).Str("tx_hash", txHash).Msg("tx not found yet; waiting...")
+			return err
+		}
+		if resp.Code != 0 {
+			return retry.Permanent(fmt.Errorf("tx failed with code %d: %s", resp.Code, resp.RawLog))
+		}
 		return nil
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return resp, fmt.Errorf("waiting for tx %s: %w", txHash, ctxErr)
+		}
+		return resp, err
+	}
+	return resp, nil
+}
+
+// LastRelayTime returns the block time of the latest relay tx signed by the
+// given address. A zero time is returned if the address never relayed.
+func (rc RelayerClient) LastRelayTime(_ context.Context, address string) (time.Time, error) {
+	clientCtx, err := rc.CreateClientContext()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	query := fmt.Sprintf("message.sender='%s' AND message.action='%s'",
+		address,
+		sdk.MsgTypeURL(&gmptypes.MsgRelayPrice{}),
+	)
+	result, err := authtx.QueryTxsByEvents(clientCtx, 1, 1, query, "desc")
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(result.Txs) == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, result.Txs[0].Timestamp)
+}
+
+// GetLatestMedian gets the latest median stamp of an asset from the Ojo node.
+func (r *RelayerClient) GetLatestMedian(ctx context.Context, denom string) (oracletypes.PriceStamp, error) {
+	grpcConn, err := dialGRPC(r.GRPCEndpoint)
+	if err != nil {
+		return oracletypes.PriceStamp{}, err
+	}
+	defer grpcConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, r.RPCTimeout)
+	defer cancel()
+
+	queryResponse, err := oracletypes.NewQueryClient(grpcConn).Medians(ctx, &oracletypes.QueryMedians{
+		Denom:     denom,
+		NumStamps: 1,
+	})
+	if err != nil {
+		return oracletypes.PriceStamp{}, err
+	}
+	if len(queryResponse.Medians) == 0 {
+		return oracletypes.PriceStamp{}, fmt.Errorf("no median stamp for %s", denom)
+	}
+
+	return queryResponse.Medians[0], nil
+}
+
+// GetOracleParams gets the params of the oracle module from the Ojo node.
+func (r *RelayerClient) GetOracleParams(ctx context.Context) (oracletypes.Params, error) {
+	grpcConn, err := dialGRPC(r.GRPCEndpoint)
+	if err != nil {
+		return oracletypes.Params{}, err
+	}
+	defer grpcConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, r.RPCTimeout)
+	defer cancel()
+
+	queryResponse, err := oracletypes.NewQueryClient(grpcConn).Params(ctx, &oracletypes.QueryParams{})
+	if err != nil {
+		return oracletypes.Params{}, err
 	}
 
-	return errors.New("broadcasting tx timed out")
+	return queryResponse.Params, nil
 }
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	searchGMPURL = "https://api.axelarscan.io/gmp/searchGMP"
+	// gmpStatusTimeout bounds a single status query to axelarscan.
+	gmpStatusTimeout = 10 * time.Second
+
+	// GMPStatusNotFound is returned when axelarscan has not indexed the call yet.
+	GMPStatusNotFound = "not_found"
+)
+
+// searchGMPRequest defines the structure of the axelarscan searchGMP request body.
+type searchGMPRequest struct {
+	TxHash string `json:"txHash"`
+}
+
+// searchGMPResponse defines the subset of the axelarscan searchGMP response we use.
+type searchGMPResponse struct {
+	Data []struct {
+		Status string `json:"status"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Error   bool   `json:"error"`
+}
+
+// GMPStatus queries axelarscan for the status of the GMP call initiated by
+// the Ojo tx with the given hash, e.g. "called", "approved" or "executed".
+// GMPStatusNotFound is returned if the call has not been indexed yet.
+func GMPStatus(ctx context.Context, txHash string) (string, error) {
+	jsonBody, err := json.Marshal(searchGMPRequest{TxHash: txHash})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", searchGMPURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{Timeout: gmpStatusTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// parse response
+	responseBody := &searchGMPResponse{}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	err = json.Unmarshal(respBody, responseBody)
+	if err != nil {
+		return "", err
+	}
+	if responseBody.Error {
+		return "", errors.New(responseBody.Message)
+	}
+	if len(responseBody.Data) == 0 {
+		return GMPStatusNotFound, nil
+	}
+
+	return responseBody.Data[0].Status, nil
+}
@@ -2,14 +2,185 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	oracletypes "github.com/ojo-network/ojo/x/oracle/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrPriceNotFound is returned when the Ojo node has no exchange rate for
+	// a denom.
+	ErrPriceNotFound = errors.New("price not found")
+	// ErrNodeUnavailable is returned when the Ojo node cannot be reached or
+	// does not answer in time.
+	ErrNodeUnavailable = errors.New("node unavailable")
 )
 
 func dialerFunc(_ context.Context, addr string) (net.Conn, error) {
 	return Connect(addr)
 }
 
+// dialGRPC opens a gRPC connection to an Ojo node.
+func dialGRPC(endpoint string) (*grpc.ClientConn, error) {
+	return grpc.Dial(
+		endpoint,
+		// the Cosmos SDK doesn't support any transport security mechanism
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(dialerFunc),
+	)
+}
+
+// QueryAcceptList returns the symbol denoms of the oracle module accept list
+// of the Ojo node at the given gRPC endpoint.
+func QueryAcceptList(ctx context.Context, grpcEndpoint string, timeout time.Duration) ([]string, error) {
+	grpcConn, err := dialGRPC(grpcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer grpcConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	queryResponse, err := oracletypes.NewQueryClient(grpcConn).Params(ctx, &oracletypes.QueryParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	denoms := make([]string, len(queryResponse.Params.AcceptList))
+	for i, d := range queryResponse.Params.AcceptList {
+		denoms[i] = d.SymbolDenom
+	}
+	return denoms, nil
+}
+
+// QueryAccountExists returns an error if the account with the given address
+// does not exist on the Ojo node at the given gRPC endpoint.
+func QueryAccountExists(ctx context.Context, grpcEndpoint string, timeout time.Duration, address string) error {
+	grpcConn, err := dialGRPC(grpcEndpoint)
+	if err != nil {
+		return err
+	}
+	defer grpcConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, err = authtypes.NewQueryClient(grpcConn).Account(ctx, &authtypes.QueryAccountRequest{
+		Address: address,
+	})
+	return err
+}
+
+// QueryBalance returns the balance of the given address in the given denom
+// on the Ojo node at the given gRPC endpoint.
+func QueryBalance(
+	ctx context.Context,
+	grpcEndpoint string,
+	timeout time.Duration,
+	address string,
+	denom string,
+) (sdk.Coin, error) {
+	grpcConn, err := dialGRPC(grpcEndpoint)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	defer grpcConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	queryResponse, err := banktypes.NewQueryClient(grpcConn).Balance(ctx, &banktypes.QueryBalanceRequest{
+		Address: address,
+		Denom:   denom,
+	})
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	return *queryResponse.Balance, nil
+}
+
+// QueryMinGasPrices returns the minimum gas prices of the Ojo node at the
+// given gRPC endpoint, which are empty if it accepts any gas price.
+func QueryMinGasPrices(ctx context.Context, grpcEndpoint string, timeout time.Duration) (sdk.DecCoins, error) {
+	grpcConn, err := dialGRPC(grpcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer grpcConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	queryResponse, err := node.NewServiceClient(grpcConn).Config(ctx, &node.ConfigRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return sdk.ParseDecCoins(queryResponse.MinimumGasPrice)
+}
+
+// QueryExchangeRate returns the exchange rate of the given denom on the Ojo
+// node at the given gRPC endpoint, as of the given height, or of the latest
+// height if zero.
+func QueryExchangeRate(
+	ctx context.Context,
+	grpcEndpoint string,
+	timeout time.Duration,
+	denom string,
+	height int64,
+) (sdk.DecCoin, error) {
+	grpcConn, err := dialGRPC(grpcEndpoint)
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("%w: %w", ErrNodeUnavailable, err)
+	}
+	defer grpcConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if height > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+	}
+
+	queryResponse, err := oracletypes.NewQueryClient(grpcConn).ExchangeRates(ctx, &oracletypes.QueryExchangeRates{
+		Denom: denom,
+	})
+	if err != nil {
+		return sdk.DecCoin{}, priceError(denom, err)
+	}
+	if queryResponse.ExchangeRates.Empty() {
+		return sdk.DecCoin{}, fmt.Errorf("%w for %s", ErrPriceNotFound, denom)
+	}
+	return queryResponse.ExchangeRates[0], nil
+}
+
+// priceError wraps the error of an exchange rate query of the given denom
+// with ErrPriceNotFound or ErrNodeUnavailable, if it is one of them.
+func priceError(denom string, err error) error {
+	s, _ := status.FromError(err)
+	switch {
+	case s.Code() == codes.NotFound || strings.Contains(s.Message(), oracletypes.ErrUnknownDenom.Error()):
+		return fmt.Errorf("%w for %s: %w", ErrPriceNotFound, denom, err)
+	case s.Code() == codes.Unavailable || s.Code() == codes.DeadlineExceeded:
+		return fmt.Errorf("%w: %w", ErrNodeUnavailable, err)
+	}
+	return err
+}
+
 // Connect dials the given address and returns a net.Conn. The protoAddr
 // argument should be prefixed with the protocol,
 // eg. "tcp://127.0.0.1:8080" or "unix:///tmp/test.sock".
@@ -0,0 +1,40 @@
+package relayer
+
+import (
+	"context"
+	"time"
+)
+
+// composedRates returns the current rate of the composed feed of the given
+// asset, and its rate as of the last relay, or the current rate if unknown.
+// It returns false if the asset has no composed feed or no rate was read.
+func (r *Relayer) composedRates(ctx context.Context, a asset, now time.Time) (float64, float64, bool) {
+	feed, ok := r.feeds[a.denom]
+	if !ok {
+		return 0, 0, false
+	}
+
+	rate, err := feed.Rate(ctx, now)
+	if err != nil {
+		r.logger.Warn().Err(err).Str("denom", a.denom).Float64("last_rate", rate).
+			Msg("unable to read the rate of the composed feed")
+	}
+	if rate <= 0 {
+		return 0, 0, false
+	}
+
+	lastRate := a.lastRate
+	if lastRate <= 0 {
+		lastRate = rate
+	}
+	return rate, lastRate, true
+}
+
+// currentRate returns the last rate read of the composed feed of the given
+// denom, or zero if it has none.
+func (r *Relayer) currentRate(denom string) float64 {
+	if feed, ok := r.feeds[denom]; ok {
+		return feed.Last()
+	}
+	return 0
+}
@@ -0,0 +1,140 @@
+package composed
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/evm"
+)
+
+const (
+	TypeMellow    = "mellow"
+	TypeInception = "inception"
+	TypeYN        = "yn"
+
+	defaultDecimals = 18
+	defaultRefresh  = time.Minute
+)
+
+// Caller executes read-only contract calls on the destination chain.
+type Caller interface {
+	Call(ctx context.Context, to string, data []byte) ([]byte, error)
+}
+
+// Feed reads the on-chain rate of a composed price feed, the way the feed
+// contract computes it, and caches it for its refresh interval.
+type Feed struct {
+	cfg    config.Composed
+	caller Caller
+
+	mtx     sync.Mutex
+	rate    float64
+	updated time.Time
+}
+
+// NewFeed returns the feed of the given config, reading its rate through the
+// given caller.
+func NewFeed(cfg config.Composed, caller Caller) (*Feed, error) {
+	switch cfg.Type {
+	case TypeMellow, TypeInception, TypeYN:
+	default:
+		return nil, fmt.Errorf("unknown composed feed type %q", cfg.Type)
+	}
+	if cfg.Decimals == 0 {
+		cfg.Decimals = defaultDecimals
+	}
+	if cfg.Refresh == 0 {
+		cfg.Refresh = defaultRefresh
+	}
+	return &Feed{cfg: cfg, caller: caller}, nil
+}
+
+// NewFeeds returns the composed feeds of the given assets, by denom, reading
+// from the given EVM JSON-RPC endpoint.
+func NewFeeds(assets []config.Assets, evmRPC string, timeout time.Duration) (map[string]*Feed, error) {
+	feeds := map[string]*Feed{}
+	client := evm.NewClient(evmRPC, timeout)
+	for _, a := range assets {
+		if a.Composed.Type == "" {
+			continue
+		}
+		feed, err := NewFeed(a.Composed, client)
+		if err != nil {
+			return nil, fmt.Errorf("composed feed of %s: %w", a.Denom, err)
+		}
+		feeds[a.Denom] = feed
+	}
+	return feeds, nil
+}
+
+// Rate returns the rate as of now, read again once the refresh interval
+// elapsed. If the read fails, the last rate is returned with the error, or
+// zero if it was never read, and kept until the next refresh.
+func (f *Feed) Rate(ctx context.Context, now time.Time) (float64, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if !f.updated.IsZero() && now.Sub(f.updated) < f.cfg.Refresh {
+		return f.rate, nil
+	}
+
+	// failed reads are retried on the next refresh too
+	f.updated = now
+	rate, err := f.read(ctx)
+	if err != nil {
+		return f.rate, err
+	}
+	f.rate = rate
+	return f.rate, nil
+}
+
+// Last returns the last rate read, or zero if it was never read.
+func (f *Feed) Last() float64 {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return f.rate
+}
+
+// read computes the rate of the feed as of the latest block.
+func (f *Feed) read(ctx context.Context) (float64, error) {
+	var raw *big.Int
+	switch f.cfg.Type {
+	case TypeMellow, TypeYN:
+		rate, err := f.callUint256(ctx, f.cfg.Contract, "getRate()")
+		if err != nil {
+			return 0, err
+		}
+		raw = rate
+	case TypeInception:
+		deposited, err := f.callUint256(ctx, f.cfg.Contract, "getTotalDeposited()")
+		if err != nil {
+			return 0, err
+		}
+		supply, err := f.callUint256(ctx, f.cfg.Token, "totalSupply()")
+		if err != nil {
+			return 0, err
+		}
+		// the feed answers zero without supply
+		raw = new(big.Int)
+		if supply.Sign() != 0 {
+			raw.Mul(deposited, big.NewInt(1e18)).Quo(raw, supply)
+		}
+	}
+
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(f.cfg.Decimals)), nil))
+	rate, _ := new(big.Float).Quo(new(big.Float).SetInt(raw), scale).Float64()
+	return rate, nil
+}
+
+func (f *Feed) callUint256(ctx context.Context, contract, signature string) (*big.Int, error) {
+	out, err := f.caller.Call(ctx, contract, evm.Selector(signature))
+	if err != nil {
+		return nil, fmt.Errorf("%s of %s: %w", signature, contract, err)
+	}
+	return evm.DecodeUint256(out, 0)
+}
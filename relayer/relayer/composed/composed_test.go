@@ -0,0 +1,132 @@
+package composed
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/evm"
+)
+
+// fakeCaller answers calls by contract and selector with uint256 values.
+type fakeCaller struct {
+	values map[string]*big.Int
+	calls  int
+}
+
+func (c *fakeCaller) Call(_ context.Context, to string, data []byte) ([]byte, error) {
+	c.calls++
+	v, ok := c.values[to+hex.EncodeToString(data)]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	return v.FillBytes(make([]byte, 32)), nil
+}
+
+func (c *fakeCaller) set(to, signature string, v *big.Int) {
+	c.values[to+hex.EncodeToString(evm.Selector(signature))] = v
+}
+
+func e18(f float64) *big.Int {
+	v, _ := new(big.Float).Mul(big.NewFloat(f), big.NewFloat(1e18)).Int(nil)
+	return v
+}
+
+func TestRate(t *testing.T) {
+	const vault, token = "0xvault", "0xtoken"
+
+	tests := []struct {
+		name    string
+		cfg     config.Composed
+		values  func(c *fakeCaller)
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:   "mellow",
+			cfg:    config.Composed{Type: TypeMellow, Contract: vault},
+			values: func(c *fakeCaller) { c.set(vault, "getRate()", e18(1.05)) },
+			want:   1.05,
+		},
+		{
+			name:   "yn with 8 decimals",
+			cfg:    config.Composed{Type: TypeYN, Contract: vault, Decimals: 8},
+			values: func(c *fakeCaller) { c.set(vault, "getRate()", big.NewInt(102000000)) },
+			want:   1.02,
+		},
+		{
+			name: "inception",
+			cfg:  config.Composed{Type: TypeInception, Contract: vault, Token: token},
+			values: func(c *fakeCaller) {
+				c.set(vault, "getTotalDeposited()", e18(2100))
+				c.set(token, "totalSupply()", e18(2000))
+			},
+			want: 1.05,
+		},
+		{
+			name: "inception without supply",
+			cfg:  config.Composed{Type: TypeInception, Contract: vault, Token: token},
+			values: func(c *fakeCaller) {
+				c.set(vault, "getTotalDeposited()", e18(2100))
+				c.set(token, "totalSupply()", big.NewInt(0))
+			},
+			want: 0,
+		},
+		{
+			name:    "reverted",
+			cfg:     config.Composed{Type: TypeMellow, Contract: vault},
+			values:  func(*fakeCaller) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caller := &fakeCaller{values: map[string]*big.Int{}}
+			tt.values(caller)
+			feed, err := NewFeed(tt.cfg, caller)
+			if err != nil {
+				t.Fatalf("NewFeed() error = %v", err)
+			}
+
+			got, err := feed.Rate(context.Background(), time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Rate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateRefresh(t *testing.T) {
+	caller := &fakeCaller{values: map[string]*big.Int{}}
+	caller.set("0xvault", "getRate()", e18(1.05))
+	feed, err := NewFeed(config.Composed{Type: TypeMellow, Contract: "0xvault", Refresh: time.Minute}, caller)
+	if err != nil {
+		t.Fatalf("NewFeed() error = %v", err)
+	}
+
+	now := time.Now()
+	for _, at := range []time.Time{now, now.Add(30 * time.Second), now.Add(time.Minute)} {
+		if _, err := feed.Rate(context.Background(), at); err != nil {
+			t.Fatalf("Rate() error = %v", err)
+		}
+	}
+	if caller.calls != 2 {
+		t.Errorf("Rate() made %d calls, want 2", caller.calls)
+	}
+
+	// the last rate is kept when a read fails
+	caller.values = map[string]*big.Int{}
+	got, err := feed.Rate(context.Background(), now.Add(2*time.Minute))
+	if err == nil || got != 1.05 {
+		t.Errorf("Rate() = %v, %v, want the last rate with an error", got, err)
+	}
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	// a Wednesday
+	from := time.Date(2024, 1, 31, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "0 * * * *", want: time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2024, 1, 31, 10, 45, 0, 0, time.UTC)},
+		{expr: "30 10 * * *", want: time.Date(2024, 2, 1, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 9-17/4 * * 1-5", want: time.Date(2024, 1, 31, 13, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// either the day of month or the day of week
+		{expr: "0 0 15 * 5", want: time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "0 0 30 2 *", "@often"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
+
+func TestMaxGap(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Duration
+	}{
+		{expr: "@hourly", want: time.Hour},
+		{expr: "0 9,17 * * *", want: 16 * time.Hour},
+		{expr: "0 0 * * 1-5", want: 72 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if got := s.MaxGap(from); got != tt.want {
+			t.Errorf("MaxGap(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+)
+
+// dropMismatched drops the denoms of the batch whose price disagrees with
+// their reference source by more than the tolerance of the asset, or whose
+// reference price is unavailable, and returns the remaining denoms. An alert
+// is raised when an asset starts failing the check.
+func (r *Relayer) dropMismatched(ctx context.Context, batch []string) []string {
+	checked := make([]string, 0, len(batch))
+	for _, denom := range batch {
+		source, ok := r.references[denom]
+		i := r.assetIndex(denom)
+		if !ok || i < 0 {
+			checked = append(checked, denom)
+			continue
+		}
+		a := &r.latestAssets[i]
+		tolerance := r.assetBounds(denom).Reference.Tolerance
+
+		var reason string
+		refPrice, err := source.Price(ctx, denom)
+		if err != nil {
+			reason = fmt.Sprintf("reference %s is unavailable: %s", source.Name(), err)
+		} else if diff, ok := agrees(a.lastObserved, refPrice, tolerance); !ok {
+			reason = fmt.Sprintf("price %v differs from %v of reference %s by %v, above tolerance %v",
+				a.lastObserved, refPrice, source.Name(), diff, tolerance)
+		}
+
+		if reason == "" {
+			if a.referenceMismatch {
+				r.logger.Info().Str("denom", denom).Str("reference", source.Name()).Msg("price agrees with the reference again")
+			}
+			a.referenceMismatch = false
+			checked = append(checked, denom)
+			continue
+		}
+
+		telemetry.IncrCounter(1, "reference", "mismatch")
+		if !a.referenceMismatch {
+			r.alert(Alert{Kind: "reference_mismatch", Denom: denom, Message: "relay refused: " + reason})
+		} else {
+			r.logger.Warn().Str("denom", denom).Msg("relay refused: " + reason)
+		}
+		a.referenceMismatch = true
+	}
+	return checked
+}
+
+// agrees returns the relative difference between a price and its reference
+// price, and true if it is within the tolerance.
+func agrees(price, refPrice, tolerance float64) (float64, bool) {
+	if refPrice <= 0 {
+		return 0, false
+	}
+	diff := policy.Change(refPrice, price)
+	return diff, diff <= tolerance
+}
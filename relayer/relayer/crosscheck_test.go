@@ -0,0 +1,68 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/reference"
+	"github.com/rs/zerolog"
+)
+
+type fakeSource struct {
+	price float64
+	err   error
+}
+
+func (s fakeSource) Name() string { return "fake" }
+
+func (s fakeSource) Price(context.Context, string) (float64, error) { return s.price, s.err }
+
+func TestDropMismatched(t *testing.T) {
+	tolerance := config.Reference{Type: "fake", Tolerance: 0.02}
+	cfg := config.Config{Assets: []config.Assets{
+		{Denom: "BTC", Reference: tolerance},
+		{Denom: "ETH", Reference: tolerance},
+		{Denom: "ATOM", Reference: tolerance},
+		{Denom: "OJO"},
+	}}
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, config.Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r.cfg = cfg
+	r.references = map[string]reference.Source{
+		"BTC":  fakeSource{price: 60500},
+		"ETH":  fakeSource{price: 3000},
+		"ATOM": fakeSource{err: errors.New("unreachable")},
+	}
+	r.latestAssets = []asset{
+		{denom: "BTC", lastObserved: 60000},
+		{denom: "ETH", lastObserved: 3300},
+		{denom: "ATOM", lastObserved: 10},
+		{denom: "OJO", lastObserved: 1},
+	}
+
+	got := r.dropMismatched(context.Background(), []string{"BTC", "ETH", "ATOM", "OJO"})
+	want := []string{"BTC", "OJO"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("dropMismatched() = %v, want %v", got, want)
+	}
+	if !r.latestAssets[1].referenceMismatch || !r.latestAssets[2].referenceMismatch {
+		t.Errorf("dropMismatched() did not flag the refused assets")
+	}
+}
+
+func TestAgrees(t *testing.T) {
+	if _, ok := agrees(101, 100, 0.02); !ok {
+		t.Errorf("agrees() = false for a 1%% difference, want true")
+	}
+	if _, ok := agrees(103, 100, 0.02); ok {
+		t.Errorf("agrees() = true for a 3%% difference, want false")
+	}
+	if _, ok := agrees(100, 0, 0.02); ok {
+		t.Errorf("agrees() = true for a zero reference price, want false")
+	}
+}
@@ -0,0 +1,233 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/evm"
+	ojoparams "github.com/ojo-network/ojo/app/params"
+	"github.com/rs/zerolog"
+)
+
+// Options defines the parameters of the preflight checks.
+type Options struct {
+	KeyringPass string
+	RPCTimeout  time.Duration
+	// MaxLatency is the highest acceptable latency of the Ojo node RPCs.
+	MaxLatency time.Duration
+}
+
+// Checks returns the preflight checks of every dependency of a relayer
+// running with the given config.
+func Checks(cfg config.Config, opts Options) []Check {
+	d := doctor{cfg: cfg, opts: opts}
+	return []Check{
+		{Name: "keyring", Run: d.checkKeyring},
+		{Name: "chain id", Run: d.checkChainID},
+		{Name: "tendermint rpc latency", Run: d.checkTMRPCLatency},
+		{Name: "grpc latency", Run: d.checkGRPCLatency},
+		{Name: "oracle prices", Run: d.checkPrices},
+		{Name: "account", Run: d.checkAccount},
+		{Name: "gas balance", Run: d.checkGasBalance},
+		{Name: "axelar gas balance", Run: d.checkAxelarGasBalance},
+		{Name: "axelarscan estimate", Run: d.checkGasEstimate},
+		{Name: "destination contract", Run: d.checkContract},
+	}
+}
+
+type doctor struct {
+	cfg  config.Config
+	opts Options
+}
+
+// relayerClient returns a relayer client that is not connected to the node.
+func (d doctor) relayerClient() (client.RelayerClient, error) {
+	relayerAddr, err := sdk.AccAddressFromBech32(d.cfg.Account.Address)
+	if err != nil {
+		return client.RelayerClient{}, err
+	}
+
+	return client.RelayerClient{
+		Logger:            zerolog.Nop(),
+		ChainID:           d.cfg.Account.ChainID,
+		KeyringBackend:    d.cfg.Keyring.Backend,
+		KeyringDir:        d.cfg.Keyring.Dir,
+		KeyringPass:       d.opts.KeyringPass,
+		TMRPC:             d.cfg.RPC.TMRPCEndpoint,
+		RPCTimeout:        d.opts.RPCTimeout,
+		RelayerAddr:       relayerAddr,
+		RelayerAddrString: d.cfg.Account.Address,
+		Encoding:          ojoparams.MakeEncodingConfig(),
+		GasPrices:         d.cfg.GasPrices,
+		Gas:               d.cfg.Gas,
+		GRPCEndpoint:      d.cfg.RPC.GRPCEndpoint,
+	}, nil
+}
+
+func (d doctor) checkKeyring(context.Context) (string, error) {
+	relayerClient, err := d.relayerClient()
+	if err != nil {
+		return "", err
+	}
+
+	// the client context looks up the key of the relayer address
+	clientCtx, err := relayerClient.CreateClientContext()
+	if err != nil {
+		return "", fmt.Errorf("no key for %s in the %s keyring: %w", d.cfg.Account.Address, d.cfg.Keyring.Backend, err)
+	}
+	return fmt.Sprintf("key %q matches %s", clientCtx.FromName, d.cfg.Account.Address), nil
+}
+
+func (d doctor) checkChainID(ctx context.Context) (string, error) {
+	rpcClient, err := rpchttp.New(d.cfg.RPC.TMRPCEndpoint, "/websocket")
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.opts.RPCTimeout)
+	defer cancel()
+
+	status, err := rpcClient.Status(ctx)
+	if err != nil {
+		return "", err
+	}
+	if status.NodeInfo.Network != d.cfg.Account.ChainID {
+		return "", fmt.Errorf("node is on %q but account.chain_id is %q", status.NodeInfo.Network, d.cfg.Account.ChainID)
+	}
+	return fmt.Sprintf("node is on %s at height %d", status.NodeInfo.Network, status.SyncInfo.LatestBlockHeight), nil
+}
+
+func (d doctor) checkTMRPCLatency(ctx context.Context) (string, error) {
+	rpcClient, err := rpchttp.New(d.cfg.RPC.TMRPCEndpoint, "/websocket")
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.opts.RPCTimeout)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := rpcClient.Health(ctx); err != nil {
+		return "", err
+	}
+	return d.latency(time.Since(start))
+}
+
+func (d doctor) checkGRPCLatency(ctx context.Context) (string, error) {
+	start := time.Now()
+	if _, err := client.QueryAcceptList(ctx, d.cfg.RPC.GRPCEndpoint, d.opts.RPCTimeout); err != nil {
+		return "", err
+	}
+	return d.latency(time.Since(start))
+}
+
+func (d doctor) latency(latency time.Duration) (string, error) {
+	if latency > d.opts.MaxLatency {
+		return "", fmt.Errorf("latency of %s is above %s", latency, d.opts.MaxLatency)
+	}
+	return fmt.Sprintf("latency of %s", latency), nil
+}
+
+func (d doctor) checkPrices(ctx context.Context) (string, error) {
+	relayerClient, err := d.relayerClient()
+	if err != nil {
+		return "", err
+	}
+
+	prices := make([]string, 0, len(d.cfg.Assets))
+	missing := []string{}
+	for _, a := range d.cfg.Assets {
+		price, err := relayerClient.GetPrice(ctx, a.Denom)
+		if err != nil || price.Amount.IsNil() || !price.Amount.IsPositive() {
+			missing = append(missing, a.Denom)
+			continue
+		}
+		prices = append(prices, fmt.Sprintf("%s=%s", a.Denom, price.Amount))
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no price for %s", strings.Join(missing, ", "))
+	}
+	return strings.Join(prices, " "), nil
+}
+
+func (d doctor) checkAccount(ctx context.Context) (string, error) {
+	err := client.QueryAccountExists(ctx, d.cfg.RPC.GRPCEndpoint, d.opts.RPCTimeout, d.cfg.Account.Address)
+	if err != nil {
+		return "", fmt.Errorf("account %s not found: %w", d.cfg.Account.Address, err)
+	}
+	return fmt.Sprintf("account %s exists", d.cfg.Account.Address), nil
+}
+
+func (d doctor) checkGasBalance(ctx context.Context) (string, error) {
+	gasPrices, err := sdk.ParseDecCoins(d.cfg.GasPrices)
+	if err != nil || gasPrices.Empty() {
+		return "no gas prices configured", ErrSkipped
+	}
+
+	// the fee of a single relay tx
+	gasPrice := gasPrices[0]
+	required := gasPrice.Amount.MulInt64(int64(d.cfg.Gas)).Ceil().TruncateInt()
+	return d.checkBalance(ctx, gasPrice.Denom, required)
+}
+
+func (d doctor) checkAxelarGasBalance(ctx context.Context) (string, error) {
+	required, ok := math.NewIntFromString(d.cfg.AxelarGas.Default)
+	if !ok {
+		return "", fmt.Errorf("invalid axelar_gas default %q", d.cfg.AxelarGas.Default)
+	}
+	return d.checkBalance(ctx, d.cfg.AxelarGas.Denom, required)
+}
+
+// checkBalance checks that the relayer account holds at least the required
+// amount of the given denom.
+func (d doctor) checkBalance(ctx context.Context, denom string, required math.Int) (string, error) {
+	balance, err := client.QueryBalance(ctx, d.cfg.RPC.GRPCEndpoint, d.opts.RPCTimeout, d.cfg.Account.Address, denom)
+	if err != nil {
+		return "", err
+	}
+	if balance.Amount.IsZero() || balance.Amount.LT(required) {
+		return "", fmt.Errorf("balance of %s is below the %s%s needed for a relay", balance, required, denom)
+	}
+	return fmt.Sprintf("balance of %s", balance), nil
+}
+
+func (d doctor) checkGasEstimate(context.Context) (string, error) {
+	fee, err := client.EstimateGasFee(
+		d.cfg.Relayer.Destination,
+		d.cfg.Relayer.Contract,
+		d.cfg.AxelarGas.Default,
+		d.cfg.AxelarGas.Multiplier,
+	)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("estimated fee of %s%s to %s", fee, d.cfg.AxelarGas.Denom, d.cfg.Relayer.Destination), nil
+}
+
+func (d doctor) checkContract(ctx context.Context) (string, error) {
+	if d.cfg.Relayer.EVMRPC == "" {
+		return "relayer.evm_rpc is not configured", ErrSkipped
+	}
+
+	evmClient := evm.NewClient(d.cfg.Relayer.EVMRPC, d.opts.RPCTimeout)
+	code, err := evmClient.GetCode(ctx, d.cfg.Relayer.Contract)
+	if err != nil {
+		return "", err
+	}
+	if len(code) == 0 {
+		return "", fmt.Errorf("no contract deployed at %s", d.cfg.Relayer.Contract)
+	}
+
+	resolveWindow, err := evmClient.ResolveWindow(ctx, d.cfg.Relayer.Contract)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("contract at %s has a resolve window of %s", d.cfg.Relayer.Contract, resolveWindow), nil
+}
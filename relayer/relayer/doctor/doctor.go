@@ -0,0 +1,75 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Status defines the outcome of a check.
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// ErrSkipped is returned by a check that does not apply to the config.
+var ErrSkipped = errors.New("skipped")
+
+type (
+	// Check defines a single preflight check. Run returns a human readable
+	// detail on success.
+	Check struct {
+		Name string
+		Run  func(ctx context.Context) (string, error)
+	}
+
+	// Result defines the outcome of a check.
+	Result struct {
+		Name     string        `json:"name"`
+		Status   Status        `json:"status"`
+		Detail   string        `json:"detail"`
+		Duration time.Duration `json:"duration"`
+	}
+)
+
+// Run runs every check in order, regardless of the outcome of the previous
+// ones, and returns their results.
+func Run(ctx context.Context, checks []Check) []Result {
+	results := make([]Result, len(checks))
+	for i, check := range checks {
+		start := time.Now()
+		detail, err := check.Run(ctx)
+
+		result := Result{
+			Name:     check.Name,
+			Status:   StatusPass,
+			Detail:   detail,
+			Duration: time.Since(start),
+		}
+		switch {
+		case errors.Is(err, ErrSkipped):
+			result.Status = StatusSkip
+			if result.Detail == "" {
+				result.Detail = err.Error()
+			}
+		case err != nil:
+			result.Status = StatusFail
+			result.Detail = err.Error()
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// Passed returns true if no check failed.
+func Passed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
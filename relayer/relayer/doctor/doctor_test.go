@@ -0,0 +1,34 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		{Name: "pass", Run: func(context.Context) (string, error) { return "ok", nil }},
+		{Name: "fail", Run: func(context.Context) (string, error) { return "", errors.New("boom") }},
+		{Name: "skip", Run: func(context.Context) (string, error) { return "not configured", ErrSkipped }},
+	}
+
+	results := Run(context.Background(), checks)
+
+	want := []Result{
+		{Name: "pass", Status: StatusPass, Detail: "ok"},
+		{Name: "fail", Status: StatusFail, Detail: "boom"},
+		{Name: "skip", Status: StatusSkip, Detail: "not configured"},
+	}
+	for i, w := range want {
+		if results[i].Name != w.Name || results[i].Status != w.Status || results[i].Detail != w.Detail {
+			t.Errorf("Run() result = %+v, want %+v", results[i], w)
+		}
+	}
+	if Passed(results) {
+		t.Errorf("Passed() = true, want false")
+	}
+	if !Passed(results[:1]) {
+		t.Errorf("Passed() = false, want true")
+	}
+}
@@ -0,0 +1,54 @@
+package election
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+const (
+	// ModeFile elects the replica holding a lock on a local file.
+	ModeFile = "file"
+	// ModeObserve elects the standby replica when the leader stops relaying.
+	ModeObserve = "observe"
+)
+
+// Elector decides whether a relayer replica is the leader. Only the leader
+// relays prices.
+type Elector interface {
+	// IsLeader returns true if the replica is currently the leader.
+	IsLeader(ctx context.Context) (bool, error)
+	// Close gives up the leadership, if held.
+	Close() error
+}
+
+// RelayObserver returns the time of the latest relay tx of an address.
+type RelayObserver interface {
+	LastRelayTime(ctx context.Context, address string) (time.Time, error)
+}
+
+// NewElector returns the elector of the given election config. If no mode is
+// configured, the replica is always the leader.
+func NewElector(cfg config.Election, interval time.Duration, observer RelayObserver) (Elector, error) {
+	switch cfg.Mode {
+	case "":
+		return AlwaysLeader{}, nil
+	case ModeFile:
+		return NewFileLock(cfg.LockFile), nil
+	case ModeObserve:
+		return NewObserver(observer, cfg.LeaderAddress, interval+cfg.Grace, cfg.CheckInterval), nil
+	default:
+		return nil, fmt.Errorf("unknown election mode %q", cfg.Mode)
+	}
+}
+
+// AlwaysLeader is the elector of a relayer running without replicas.
+type AlwaysLeader struct{}
+
+// IsLeader implements Elector.
+func (AlwaysLeader) IsLeader(context.Context) (bool, error) { return true, nil }
+
+// Close implements Elector.
+func (AlwaysLeader) Close() error { return nil }
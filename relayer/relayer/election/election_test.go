@@ -0,0 +1,71 @@
+package election
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type mockObserver struct {
+	lastRelay time.Time
+}
+
+func (m *mockObserver) LastRelayTime(context.Context, string) (time.Time, error) {
+	return m.lastRelay, nil
+}
+
+func TestObserver(t *testing.T) {
+	now := time.Now()
+	relays := &mockObserver{lastRelay: now.Add(-time.Hour)}
+	o := NewObserver(relays, "ojo1leader", 2*time.Hour, time.Minute)
+	o.now = func() time.Time { return now }
+
+	check := func(want bool) {
+		t.Helper()
+		got, err := o.IsLeader(context.Background())
+		if err != nil {
+			t.Fatalf("IsLeader() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("IsLeader() = %v, want %v", got, want)
+		}
+	}
+
+	// the leader relayed within its window
+	check(false)
+
+	// the leader stopped relaying, but the chain is not queried again yet
+	now = now.Add(30 * time.Second)
+	relays.lastRelay = now.Add(-3 * time.Hour)
+	check(false)
+
+	// take over once the window elapsed
+	now = now.Add(time.Minute)
+	check(true)
+
+	// step down once the leader relays again
+	now = now.Add(time.Minute)
+	relays.lastRelay = now
+	check(false)
+}
+
+func TestFileLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relayer.lock")
+	leader, standby := NewFileLock(path), NewFileLock(path)
+
+	if ok, err := leader.IsLeader(context.Background()); err != nil || !ok {
+		t.Fatalf("leader IsLeader() = %v, %v, want true", ok, err)
+	}
+	if ok, err := standby.IsLeader(context.Background()); err != nil || ok {
+		t.Fatalf("standby IsLeader() = %v, %v, want false", ok, err)
+	}
+
+	// failover once the leader releases the lock
+	if err := leader.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if ok, err := standby.IsLeader(context.Background()); err != nil || !ok {
+		t.Fatalf("standby IsLeader() = %v, %v, want true", ok, err)
+	}
+}
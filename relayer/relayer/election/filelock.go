@@ -0,0 +1,70 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// FileLock elects the replica holding an exclusive lock on a local file, for
+// failover between replicas running on the same host. The lock is released
+// by the OS if the leader process dies.
+type FileLock struct {
+	path string
+
+	mtx  sync.Mutex
+	file *os.File
+}
+
+// NewFileLock returns an elector locking the file at the given path.
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+// IsLeader implements Elector. It attempts to take the lock if it is not held yet.
+func (l *FileLock) IsLeader(context.Context) (bool, error) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.file != nil {
+		return true, nil
+	}
+
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return false, err
+	}
+	if err := tryLock(f); err != nil {
+		f.Close()
+		if errors.Is(err, errLocked) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to lock %s: %w", l.path, err)
+	}
+
+	// record the pid of the leader for operators
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	l.file = f
+	return true, nil
+}
+
+// Close implements Elector.
+func (l *FileLock) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
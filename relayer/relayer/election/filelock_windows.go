@@ -0,0 +1,18 @@
+//go:build windows
+
+package election
+
+import (
+	"errors"
+	"os"
+)
+
+var errLocked = errors.New("file is locked by another process")
+
+func tryLock(*os.File) error {
+	return errors.New("file lock election is not supported on windows")
+}
+
+func unlock(*os.File) error {
+	return nil
+}
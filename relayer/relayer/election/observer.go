@@ -0,0 +1,82 @@
+package election
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Observer elects a standby replica by watching the relay txs of the leader
+// on the Ojo chain. The standby takes over when the leader has not relayed
+// within its heartbeat interval plus a grace period, and steps down as soon as
+// the leader relays again.
+type Observer struct {
+	observer      RelayObserver
+	leaderAddress string
+	window        time.Duration
+	checkInterval time.Duration
+	now           func() time.Time
+
+	mtx       sync.Mutex
+	lastCheck time.Time
+	leader    bool
+	takeover  time.Time
+}
+
+// NewObserver returns an elector watching the relays of the leader address.
+// The chain is queried at most once every check interval.
+func NewObserver(
+	observer RelayObserver,
+	leaderAddress string,
+	window time.Duration,
+	checkInterval time.Duration,
+) *Observer {
+	return &Observer{
+		observer:      observer,
+		leaderAddress: leaderAddress,
+		window:        window,
+		checkInterval: checkInterval,
+		now:           time.Now,
+	}
+}
+
+// IsLeader implements Elector. If the chain cannot be queried, the current
+// leadership is kept and the error is returned.
+func (o *Observer) IsLeader(ctx context.Context) (bool, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	now := o.now()
+	if !o.lastCheck.IsZero() && now.Sub(o.lastCheck) < o.checkInterval {
+		return o.leader, nil
+	}
+
+	lastRelay, err := o.observer.LastRelayTime(ctx, o.leaderAddress)
+	if err != nil {
+		return o.leader, err
+	}
+	o.lastCheck = now
+
+	if o.leader {
+		// the leader is back
+		if lastRelay.After(o.takeover) {
+			o.leader = false
+		}
+		return o.leader, nil
+	}
+
+	if now.Sub(lastRelay) > o.window {
+		o.leader = true
+		o.takeover = now
+	}
+	return o.leader, nil
+}
+
+// Close implements Elector.
+func (o *Observer) Close() error {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.leader = false
+	return nil
+}
@@ -0,0 +1,26 @@
+package evm
+
+import (
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Selector returns the 4 bytes function selector of a function signature,
+// e.g. "resolveWindow()".
+func Selector(signature string) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(signature))
+	return hash.Sum(nil)[:4]
+}
+
+// DecodeUint256 decodes the ABI encoded uint256 at the given word index of a
+// call output.
+func DecodeUint256(out []byte, index int) (*big.Int, error) {
+	start := index * 32
+	if len(out) < start+32 {
+		return nil, fmt.Errorf("output too short to decode word %d: %d bytes", index, len(out))
+	}
+	return new(big.Int).SetBytes(out[start : start+32]), nil
+}
@@ -0,0 +1,29 @@
+package evm
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestSelector(t *testing.T) {
+	if got := hex.EncodeToString(Selector("transfer(address,uint256)")); got != "a9059cbb" {
+		t.Errorf("Selector() = %v, want %v", got, "a9059cbb")
+	}
+}
+
+func TestDecodeUint256(t *testing.T) {
+	out := make([]byte, 64)
+	out[63] = 42
+
+	got, err := DecodeUint256(out, 1)
+	if err != nil {
+		t.Fatalf("DecodeUint256() error = %v", err)
+	}
+	if got.Int64() != 42 {
+		t.Errorf("DecodeUint256() = %v, want %v", got, 42)
+	}
+
+	if _, err := DecodeUint256(out, 2); err == nil {
+		t.Errorf("DecodeUint256() expected error for short output")
+	}
+}
@@ -0,0 +1,147 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Client is a minimal EVM JSON-RPC client used to read the state of the
+	// destination chain.
+	Client struct {
+		url        string
+		httpClient *http.Client
+		nextID     atomic.Uint64
+	}
+
+	rpcRequest struct {
+		JSONRPC string        `json:"jsonrpc"`
+		ID      uint64        `json:"id"`
+		Method  string        `json:"method"`
+		Params  []interface{} `json:"params"`
+	}
+
+	rpcResponse struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+
+	callArgs struct {
+		To   string `json:"to"`
+		Data string `json:"data"`
+	}
+)
+
+// NewClient returns a client of the EVM JSON-RPC endpoint at the given url.
+func NewClient(url string, timeout time.Duration) *Client {
+	return &Client{
+		url:        url,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// call performs a JSON-RPC request and decodes its result.
+func (c *Client) call(ctx context.Context, method string, result interface{}, params ...interface{}) error {
+	jsonBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      c.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	responseBody := &rpcResponse{}
+	if err := json.Unmarshal(respBody, responseBody); err != nil {
+		return fmt.Errorf("%s: invalid response: %w", method, err)
+	}
+	if responseBody.Error != nil {
+		return fmt.Errorf("%s: %s (code %d)", method, responseBody.Error.Message, responseBody.Error.Code)
+	}
+
+	return json.Unmarshal(responseBody.Result, result)
+}
+
+// ChainID returns the chain ID of the EVM chain.
+func (c *Client) ChainID(ctx context.Context) (uint64, error) {
+	var result string
+	if err := c.call(ctx, "eth_chainId", &result); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimPrefix(result, "0x"), 16, 64)
+}
+
+// GetCode returns the code deployed at the given address.
+func (c *Client) GetCode(ctx context.Context, address string) ([]byte, error) {
+	var result string
+	if err := c.call(ctx, "eth_getCode", &result, address, "latest"); err != nil {
+		return nil, err
+	}
+	return decodeHex(result)
+}
+
+// Call executes a read-only call of the contract at the given address
+// against the latest block and returns its output.
+func (c *Client) Call(ctx context.Context, to string, data []byte) ([]byte, error) {
+	var result string
+	args := callArgs{
+		To:   to,
+		Data: "0x" + hex.EncodeToString(data),
+	}
+	if err := c.call(ctx, "eth_call", &result, args, "latest"); err != nil {
+		return nil, err
+	}
+	return decodeHex(result)
+}
+
+// ResolveWindow returns the resolve window of the Ojo contract at the given
+// address: prices are only posted if delivered within the window after their
+// resolve time.
+func (c *Client) ResolveWindow(ctx context.Context, contract string) (time.Duration, error) {
+	out, err := c.Call(ctx, contract, Selector("resolveWindow()"))
+	if err != nil {
+		return 0, err
+	}
+	seconds, err := DecodeUint256(out, 0)
+	if err != nil {
+		return 0, err
+	}
+	if !seconds.IsInt64() || seconds.Int64() > int64(math.MaxInt64/time.Second) {
+		return 0, fmt.Errorf("resolve window of %s is out of range: %s", contract, seconds)
+	}
+	return time.Duration(seconds.Int64()) * time.Second, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
@@ -0,0 +1,76 @@
+package relayer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/hashicorp/go-metrics"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/retry"
+)
+
+// maxPriceBackoff bounds the wait before reading again the price of an asset
+// whose reads keep failing.
+const maxPriceBackoff = time.Minute
+
+// priceBackoff returns the wait before reading again the price of an asset
+// after the given number of consecutive failed reads, doubling from one tick
+// up to maxPriceBackoff.
+func priceBackoff(failures int) time.Duration {
+	return retry.Backoff(config.Retry{Base: tickerSleep, Max: maxPriceBackoff}, failures)
+}
+
+// priceErrorReason returns the metric label of a failed price read.
+func priceErrorReason(err error) string {
+	switch {
+	case errors.Is(err, client.ErrPriceNotFound):
+		return "not_found"
+	case errors.Is(err, client.ErrNodeUnavailable):
+		return "unavailable"
+	default:
+		return "other"
+	}
+}
+
+// backingOff returns true if the price of the asset is not read again yet
+// after a failed read.
+func (a asset) backingOff(now time.Time) bool {
+	return a.failures > 0 && now.Before(a.retryAt)
+}
+
+// failing returns true if the last price read of the given denom failed.
+func (r *Relayer) failing(denom string) bool {
+	i := r.assetIndex(denom)
+	return i >= 0 && r.latestAssets[i].failures > 0
+}
+
+// priceFailed records a failed price read of the asset at the given index,
+// which is left out of the relays until a read succeeds again.
+func (r *Relayer) priceFailed(i int, err error, now time.Time) {
+	a := &r.latestAssets[i]
+	a.failures++
+	a.lastError = err
+	a.retryAt = now.Add(priceBackoff(a.failures))
+
+	reason := priceErrorReason(err)
+	telemetry.IncrCounterWithLabels([]string{"failure", "price"}, 1, []metrics.Label{
+		telemetry.NewLabel("denom", a.denom),
+		telemetry.NewLabel("reason", reason),
+	})
+	r.logger.Err(err).Str("denom", a.denom).
+		Str("reason", reason).
+		Int("failures", a.failures).
+		Time("retry_at", a.retryAt).
+		Msg("unable to get price")
+}
+
+// priceRecovered clears the failures of the asset at the given index.
+func (r *Relayer) priceRecovered(i int) {
+	a := &r.latestAssets[i]
+	if a.failures > 0 {
+		r.logger.Info().Str("denom", a.denom).Int("failures", a.failures).Msg("price read recovered")
+	}
+	a.failures, a.lastError, a.retryAt = 0, nil, time.Time{}
+}
@@ -0,0 +1,81 @@
+package relayer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/rs/zerolog"
+)
+
+func TestPriceBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: tickerSleep},
+		{failures: 2, want: 2 * tickerSleep},
+		{failures: 4, want: 8 * tickerSleep},
+		{failures: 100, want: maxPriceBackoff},
+	}
+	for _, tc := range tests {
+		if got := priceBackoff(tc.failures); got != tc.want {
+			t.Errorf("priceBackoff(%d) = %s, want %s", tc.failures, got, tc.want)
+		}
+	}
+}
+
+func TestPriceErrorReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: fmt.Errorf("%w for ETH", client.ErrPriceNotFound), want: "not_found"},
+		{err: fmt.Errorf("%w: connection refused", client.ErrNodeUnavailable), want: "unavailable"},
+		{err: fmt.Errorf("invalid price"), want: "other"},
+	}
+	for _, tc := range tests {
+		if got := priceErrorReason(tc.err); got != tc.want {
+			t.Errorf("priceErrorReason(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestPriceFailed(t *testing.T) {
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, config.Config{
+		Pairs: []config.Pair{{Base: "ETH", Quote: "BTC"}},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r.cfg.Relayer.BatchWindow = time.Second
+	r.latestAssets = []asset{
+		{denom: "ETH", lastObserved: 2000},
+		{denom: "BTC", lastObserved: 60000},
+	}
+
+	now := time.Now()
+	r.priceFailed(0, fmt.Errorf("%w for ETH", client.ErrPriceNotFound), now)
+	r.priceFailed(0, fmt.Errorf("%w for ETH", client.ErrPriceNotFound), now)
+	eth := r.latestAssets[0]
+	if eth.failures != 2 || !eth.backingOff(now.Add(tickerSleep)) || eth.backingOff(now.Add(2*tickerSleep)) {
+		t.Errorf("priceFailed() failures = %d, retry at %s, want 2 failures retried after %s",
+			eth.failures, eth.retryAt.Sub(now), 2*tickerSleep)
+	}
+
+	// unreadable assets are left out of pairs and closing windows
+	if got := r.pairTriggers(now); len(got) != 0 {
+		t.Errorf("pairTriggers() = %v, want none", got)
+	}
+	r.collect([]string{"ETH", "BTC"}, false, now, 0)
+	if got := r.collect([]string{}, false, now.Add(time.Second), 0); len(got) != 1 || got[0] != "BTC" {
+		t.Errorf("collect() = %v, want BTC", got)
+	}
+
+	r.priceRecovered(0)
+	if eth := r.latestAssets[0]; eth.failures != 0 || eth.lastError != nil || eth.backingOff(now) {
+		t.Errorf("priceRecovered() left %d failures, error %v", eth.failures, eth.lastError)
+	}
+}
@@ -0,0 +1,141 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/cron"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/evm"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+)
+
+// ErrFreshness is returned when the relays cannot keep the prices of the
+// destination fresh.
+var ErrFreshness = errors.New("relays cannot keep the destination fresh")
+
+// initFreshness reads the resolve window of the destination contract, if an
+// EVM RPC endpoint is configured, and checks the relays against it and the
+// staleness limit of the consumers.
+func (r *Relayer) initFreshness(ctx context.Context) error {
+	if r.cfg.Relayer.EVMRPC != "" {
+		client := evm.NewClient(r.cfg.Relayer.EVMRPC, r.relayerClient.RPCTimeout)
+		resolveWindow, err := client.ResolveWindow(ctx, r.cfg.Relayer.Contract)
+		if err != nil {
+			// the staleness limit of the consumers still applies
+			r.logger.Err(err).Msg("unable to read the resolve window of the destination contract")
+		} else {
+			r.resolveWindow = resolveWindow
+		}
+	}
+
+	if err := checkFreshness(r.cfg, r.resolveWindow, time.Now()); err != nil {
+		return err
+	}
+	if m := r.cfg.Relayer.MaxStaleness; m > 0 {
+		r.logger.Info().
+			Dur("resolve_window", r.resolveWindow).
+			Dur("max_staleness", m).
+			Dur("delivery_latency", r.cfg.Relayer.DeliveryLatency).
+			Msgf("relaying before prices are %s old on the destination", m)
+	}
+	return nil
+}
+
+// heartbeatOf returns the longest time between two heartbeats of the given
+// policy, with the schedule and its jitter if any, and false if the policy
+// does not relay on a heartbeat.
+func heartbeatOf(p config.Policy, defaults config.Relayer, now time.Time) (time.Duration, bool, error) {
+	switch p.Type {
+	case "":
+		// the default policy relays on the heartbeat of the relayer section
+		return heartbeatOf(config.Policy{Type: policy.TypeHeartbeat}, defaults, now)
+	case policy.TypeHeartbeat:
+		expr, jitter := p.Schedule, p.Jitter
+		if expr == "" && p.Interval == 0 {
+			expr = defaults.Schedule
+		}
+		if jitter == 0 {
+			jitter = defaults.Jitter
+		}
+		if expr == "" {
+			if p.Interval == 0 {
+				return defaults.Interval, true, nil
+			}
+			return p.Interval, true, nil
+		}
+		schedule, err := cron.Parse(expr)
+		if err != nil {
+			return 0, false, err
+		}
+		return schedule.MaxGap(now) + jitter, true, nil
+	case policy.TypeOr:
+		// the first heartbeat of the nested policies relays
+		var heartbeat time.Duration
+		found := false
+		for _, nested := range p.Policies {
+			h, ok, err := heartbeatOf(nested, defaults, now)
+			if err != nil {
+				return 0, false, err
+			}
+			if ok && (!found || h < heartbeat) {
+				heartbeat, found = h, true
+			}
+		}
+		return heartbeat, found, nil
+	case policy.TypeAnd:
+		// every nested policy must relay, on its heartbeat
+		var heartbeat time.Duration
+		for _, nested := range p.Policies {
+			h, ok, err := heartbeatOf(nested, defaults, now)
+			if err != nil || !ok {
+				return 0, false, err
+			}
+			heartbeat = max(heartbeat, h)
+		}
+		return heartbeat, len(p.Policies) > 0, nil
+	default:
+		return 0, false, nil
+	}
+}
+
+// checkFreshness returns an error if the relays could reach the destination
+// after the resolve window of its contract, or if a heartbeat of an asset
+// could reach it after the staleness limit of its consumers.
+func checkFreshness(cfg config.Config, resolveWindow time.Duration, now time.Time) error {
+	// the contract drops the prices delivered after the resolve window
+	if resolveWindow > 0 && cfg.Relayer.DeliveryLatency >= resolveWindow {
+		return fmt.Errorf("%w: delivery latency of %s exceeds the resolve window of %s",
+			ErrFreshness, cfg.Relayer.DeliveryLatency, resolveWindow)
+	}
+
+	maxStaleness := cfg.Relayer.MaxStaleness
+	if maxStaleness == 0 {
+		return nil
+	}
+	for _, a := range cfg.Assets {
+		heartbeat, ok, err := heartbeatOf(a.Policy, cfg.Relayer, now)
+		if err != nil {
+			return fmt.Errorf("policy of %s: %w", a.Denom, err)
+		}
+		// assets without heartbeat rely on the safety relays
+		if ok && heartbeat+cfg.Relayer.DeliveryLatency > maxStaleness {
+			return fmt.Errorf("%w: heartbeat of %s of %s plus a delivery latency of %s exceeds the max staleness of %s",
+				ErrFreshness, a.Denom, heartbeat, cfg.Relayer.DeliveryLatency, maxStaleness)
+		}
+	}
+	return nil
+}
+
+// safetyDue returns true if a relay of the given asset must go out now to
+// reach the destination before its consumers find its last relayed price
+// stale.
+func (r *Relayer) safetyDue(a asset, now time.Time) bool {
+	maxStaleness := r.cfg.Relayer.MaxStaleness
+	if maxStaleness == 0 || a.lastRelay.IsZero() {
+		return false
+	}
+	return now.Sub(a.lastRelay) >= maxStaleness-r.cfg.Relayer.DeliveryLatency
+}
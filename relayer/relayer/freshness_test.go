@@ -0,0 +1,112 @@
+package relayer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+func TestCheckFreshness(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	eth := []config.Assets{{Denom: "ETH"}}
+
+	tests := []struct {
+		name          string
+		relayer       config.Relayer
+		assets        []config.Assets
+		resolveWindow time.Duration
+		wantErr       bool
+	}{
+		{
+			name:    "unknown window and staleness",
+			relayer: config.Relayer{Interval: 24 * time.Hour},
+			assets:  eth,
+		},
+		{
+			// the resolve window bounds the delivery, not the price age
+			name:          "heartbeat longer than the resolve window",
+			relayer:       config.Relayer{Interval: 24 * time.Hour, DeliveryLatency: 10 * time.Second},
+			assets:        eth,
+			resolveWindow: 100 * time.Second,
+		},
+		{
+			name:          "delivery beyond the resolve window",
+			relayer:       config.Relayer{Interval: time.Hour, DeliveryLatency: 10 * time.Minute},
+			assets:        eth,
+			resolveWindow: 100 * time.Second,
+			wantErr:       true,
+		},
+		{
+			name:    "within consumer staleness",
+			relayer: config.Relayer{Interval: time.Hour, DeliveryLatency: 10 * time.Minute, MaxStaleness: 2 * time.Hour},
+			assets:  eth,
+		},
+		{
+			name:    "beyond consumer staleness",
+			relayer: config.Relayer{Interval: time.Hour, DeliveryLatency: 10 * time.Minute, MaxStaleness: time.Hour},
+			assets:  eth,
+			wantErr: true,
+		},
+		{
+			name:    "schedule with jitter",
+			relayer: config.Relayer{Schedule: "0 */2 * * *", Jitter: time.Minute, DeliveryLatency: 10 * time.Minute, MaxStaleness: 2 * time.Hour},
+			assets:  eth,
+			wantErr: true,
+		},
+		{
+			name:    "asset interval beyond consumer staleness",
+			relayer: config.Relayer{Interval: time.Hour, DeliveryLatency: 10 * time.Minute, MaxStaleness: 2 * time.Hour},
+			assets: []config.Assets{
+				{Denom: "ETH"},
+				{Denom: "BTC", Policy: config.Policy{Type: "heartbeat", Interval: 4 * time.Hour}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "asset schedule beyond consumer staleness",
+			relayer: config.Relayer{Interval: time.Hour, DeliveryLatency: 10 * time.Minute, MaxStaleness: 2 * time.Hour},
+			assets: []config.Assets{
+				{Denom: "BTC", Policy: config.Policy{Type: "or", Policies: []config.Policy{
+					{Type: "heartbeat", Schedule: "@daily"},
+					{Type: "deviation"},
+				}}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "deviation only asset",
+			relayer: config.Relayer{Interval: time.Hour, DeliveryLatency: 10 * time.Minute, MaxStaleness: 2 * time.Hour},
+			assets:  []config.Assets{{Denom: "BTC", Policy: config.Policy{Type: "deviation"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFreshness(config.Config{Relayer: tt.relayer, Assets: tt.assets}, tt.resolveWindow, now)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrFreshness)) {
+				t.Errorf("checkFreshness() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSafetyDue(t *testing.T) {
+	r := &Relayer{
+		cfg:           config.Config{Relayer: config.Relayer{MaxStaleness: time.Hour, DeliveryLatency: 10 * time.Minute}},
+		resolveWindow: 100 * time.Second,
+	}
+	now := time.Now()
+
+	if r.safetyDue(asset{lastRelay: now.Add(-49 * time.Minute)}, now) {
+		t.Errorf("safetyDue() = true, want false before the max staleness minus the latency")
+	}
+	if !r.safetyDue(asset{lastRelay: now.Add(-50 * time.Minute)}, now) {
+		t.Errorf("safetyDue() = false, want true once the max staleness minus the latency elapsed")
+	}
+	r.cfg.Relayer.MaxStaleness = 0
+	if r.safetyDue(asset{lastRelay: now.Add(-24 * time.Hour)}, now) {
+		t.Errorf("safetyDue() = true, want false without max staleness")
+	}
+}
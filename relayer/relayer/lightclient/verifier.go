@@ -0,0 +1,164 @@
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/rootmulti"
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/light"
+	dbs "github.com/cometbft/cometbft/light/store/db"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	oracletypes "github.com/ojo-network/ojo/x/oracle/types"
+)
+
+// oracleKeyPath is the ABCI query path of the raw keys of the oracle store.
+const oracleKeyPath = "/store/" + oracletypes.StoreKey + "/key"
+
+// ErrUnverified is returned when a query result cannot be verified.
+var ErrUnverified = errors.New("unverified query result")
+
+type (
+	// abciClient queries the store of the primary node.
+	abciClient interface {
+		ABCIQueryWithOptions(
+			ctx context.Context,
+			path string,
+			data cmtbytes.HexBytes,
+			opts rpcclient.ABCIQueryOptions,
+		) (*ctypes.ResultABCIQuery, error)
+	}
+
+	// lightClient verifies the light blocks holding the app hashes.
+	lightClient interface {
+		VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*cmttypes.LightBlock, error)
+		Cleanup() error
+	}
+)
+
+// Verifier reads oracle exchange rates as ABCI store queries and verifies
+// their merkle proofs against the app hashes of light blocks.
+type Verifier struct {
+	rpc     abciClient
+	lc      lightClient
+	prt     *merkle.ProofRuntime
+	timeout time.Duration
+}
+
+// NewVerifier returns a verifier querying the primary CometBFT RPC endpoint.
+// Its light client starts from the trusted header of the config.
+func NewVerifier(
+	ctx context.Context,
+	cfg config.LightClient,
+	chainID string,
+	primary string,
+	timeout time.Duration,
+) (*Verifier, error) {
+	trustedHash, err := hex.DecodeString(cfg.TrustedHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted hash: %w", err)
+	}
+
+	lc, err := light.NewHTTPClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: cfg.TrustingPeriod,
+			Height: cfg.TrustedHeight,
+			Hash:   trustedHash,
+		},
+		primary,
+		cfg.Witnesses,
+		dbs.New(dbm.NewMemDB(), ""),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rpc, err := rpchttp.New(primary, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		rpc:     rpc,
+		lc:      lc,
+		prt:     rootmulti.DefaultProofRuntime(),
+		timeout: timeout,
+	}, nil
+}
+
+// ExchangeRate returns the exchange rate of the given denom as of the given
+// height. The result is only returned if its merkle proof verifies against
+// the app hash of the light block at height + 1, and a missing rate only if
+// its absence proof does.
+func (v *Verifier) ExchangeRate(ctx context.Context, denom string, height int64) (math.LegacyDec, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	// the oracle stores the rates under the upper case denoms
+	key := oracletypes.GetExchangeRateKey(strings.ToUpper(denom))
+	res, err := v.rpc.ABCIQueryWithOptions(ctx, oracleKeyPath, key, rpcclient.ABCIQueryOptions{
+		Height: height,
+		Prove:  true,
+	})
+	if err != nil {
+		return math.LegacyDec{}, fmt.Errorf("%w: %w", client.ErrNodeUnavailable, err)
+	}
+
+	resp := res.Response
+	switch {
+	case resp.IsErr():
+		return math.LegacyDec{}, fmt.Errorf("query of %s failed with code %d: %s", denom, resp.Code, resp.Log)
+	case !bytes.Equal(resp.Key, key):
+		return math.LegacyDec{}, fmt.Errorf("%w: response key %X does not match %X", ErrUnverified, resp.Key, key)
+	case resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0:
+		return math.LegacyDec{}, fmt.Errorf("%w: no proof for %s", ErrUnverified, denom)
+	case resp.Height <= 0:
+		return math.LegacyDec{}, fmt.Errorf("%w: invalid height %d", ErrUnverified, resp.Height)
+	}
+
+	// the app hash of the state at height H is in the header of H + 1
+	lb, err := v.lc.VerifyLightBlockAtHeight(ctx, resp.Height+1, time.Now())
+	if err != nil {
+		return math.LegacyDec{}, fmt.Errorf("%w: light block %d: %s", ErrUnverified, resp.Height+1, err)
+	}
+
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(oracletypes.StoreKey), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingURL)
+	if len(resp.Value) == 0 {
+		if err := v.prt.VerifyAbsence(resp.ProofOps, lb.AppHash, keyPath.String()); err != nil {
+			return math.LegacyDec{}, fmt.Errorf("%w: absence of %s: %s", ErrUnverified, denom, err)
+		}
+		return math.LegacyDec{}, fmt.Errorf("%w for %s at height %d", client.ErrPriceNotFound, denom, resp.Height)
+	}
+	if err := v.prt.VerifyValue(resp.ProofOps, lb.AppHash, keyPath.String(), resp.Value); err != nil {
+		return math.LegacyDec{}, fmt.Errorf("%w: %s", ErrUnverified, err)
+	}
+
+	var rate sdk.DecProto
+	if err := rate.Unmarshal(resp.Value); err != nil {
+		return math.LegacyDec{}, err
+	}
+	return rate.Dec, nil
+}
+
+// Close releases the light client store.
+func (v *Verifier) Close() error {
+	return v.lc.Cleanup()
+}
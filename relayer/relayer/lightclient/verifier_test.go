@@ -0,0 +1,174 @@
+package lightclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	oracletypes "github.com/ojo-network/ojo/x/oracle/types"
+)
+
+// fakeNode serves the queries of an in-memory oracle store.
+type fakeNode struct {
+	store *rootmulti.Store
+	// tamper edits the responses of the queries
+	tamper func(resp *abci.ResponseQuery)
+}
+
+func (n fakeNode) ABCIQueryWithOptions(
+	_ context.Context,
+	path string,
+	data cmtbytes.HexBytes,
+	opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	// the store is queried without the leading "/store"
+	res, err := n.store.Query(&storetypes.RequestQuery{
+		Path:   path[len("/store"):],
+		Data:   data,
+		Height: opts.Height,
+		Prove:  opts.Prove,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := abci.ResponseQuery{Key: res.Key, Value: res.Value, ProofOps: res.ProofOps, Height: res.Height}
+	if n.tamper != nil {
+		n.tamper(&resp)
+	}
+	return &ctypes.ResultABCIQuery{Response: resp}, nil
+}
+
+// fakeLightClient returns light blocks with the app hash of the store.
+type fakeLightClient struct {
+	appHash []byte
+}
+
+func (c fakeLightClient) VerifyLightBlockAtHeight(_ context.Context, height int64, _ time.Time) (*cmttypes.LightBlock, error) {
+	return &cmttypes.LightBlock{SignedHeader: &cmttypes.SignedHeader{
+		Header: &cmttypes.Header{Height: height, AppHash: c.appHash},
+	}}, nil
+}
+
+func (c fakeLightClient) Cleanup() error {
+	return nil
+}
+
+// newTestVerifier returns a verifier of a store holding the given rates.
+func newTestVerifier(t *testing.T, rates map[string]math.LegacyDec, tamper func(resp *abci.ResponseQuery)) *Verifier {
+	t.Helper()
+
+	store := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	key := storetypes.NewKVStoreKey(oracletypes.StoreKey)
+	store.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+	if err := store.LoadLatestVersion(); err != nil {
+		t.Fatalf("LoadLatestVersion() error = %v", err)
+	}
+	kv := store.GetCommitKVStore(key)
+	for denom, rate := range rates {
+		value, err := (&sdk.DecProto{Dec: rate}).Marshal()
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		kv.Set(oracletypes.GetExchangeRateKey(denom), value)
+	}
+	commit := store.Commit()
+
+	return &Verifier{
+		rpc:     fakeNode{store: store, tamper: tamper},
+		lc:      fakeLightClient{appHash: commit.Hash},
+		prt:     rootmulti.DefaultProofRuntime(),
+		timeout: time.Second,
+	}
+}
+
+func TestExchangeRate(t *testing.T) {
+	rates := map[string]math.LegacyDec{
+		"ETH": math.LegacyMustNewDecFromStr("3000.5"),
+		"BTC": math.LegacyMustNewDecFromStr("60000"),
+	}
+
+	tests := []struct {
+		name    string
+		denom   string
+		tamper  func(resp *abci.ResponseQuery)
+		want    math.LegacyDec
+		wantErr error
+	}{
+		{
+			name:  "valid proof",
+			denom: "ETH",
+			want:  rates["ETH"],
+		},
+		{
+			name:  "lower case denom",
+			denom: "eth",
+			want:  rates["ETH"],
+		},
+		{
+			name:    "missing rate",
+			denom:   "ATOM",
+			wantErr: client.ErrPriceNotFound,
+		},
+		{
+			name:  "key mismatch",
+			denom: "ETH",
+			tamper: func(resp *abci.ResponseQuery) {
+				resp.Key = oracletypes.GetExchangeRateKey("BTC")
+			},
+			wantErr: ErrUnverified,
+		},
+		{
+			name:  "missing proof",
+			denom: "ETH",
+			tamper: func(resp *abci.ResponseQuery) {
+				resp.ProofOps = nil
+			},
+			wantErr: ErrUnverified,
+		},
+		{
+			name:  "tampered value",
+			denom: "ETH",
+			tamper: func(resp *abci.ResponseQuery) {
+				resp.Value, _ = (&sdk.DecProto{Dec: math.LegacyNewDec(1)}).Marshal()
+			},
+			wantErr: ErrUnverified,
+		},
+		{
+			// an existing rate is not reported missing without an absence
+			// proof
+			name:  "empty value",
+			denom: "ETH",
+			tamper: func(resp *abci.ResponseQuery) {
+				resp.Value = nil
+			},
+			wantErr: ErrUnverified,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := newTestVerifier(t, rates, tc.tamper)
+			got, err := v.ExchangeRate(context.Background(), tc.denom, 0)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ExchangeRate() error = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr == nil && !got.Equal(tc.want) {
+				t.Errorf("ExchangeRate() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package relayer
+
+import (
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+)
+
+// pairPolicy returns the policy of a pair, relaying on a heartbeat or a
+// deviation of its ratio.
+func pairPolicy(p config.Pair, defaults config.Relayer) policy.RelayPolicy {
+	heartbeat, deviation := p.Heartbeat, p.Deviation
+	if heartbeat == 0 {
+		heartbeat = defaults.Interval
+	}
+	if deviation == 0 {
+		deviation = defaults.Deviation
+	}
+	return policy.Or{policy.Heartbeat{Interval: heartbeat}, policy.Deviation{Threshold: deviation}}
+}
+
+// pairTriggers returns the legs of the pairs whose ratio deviated from the
+// ratio of the relayed prices of its legs, or whose legs were not both
+// relayed within the heartbeat, so that they are relayed together. Pairs
+// with a halted, unobserved or unreadable leg are skipped.
+func (r *Relayer) pairTriggers(now time.Time) []string {
+	denoms := []string{}
+	for _, p := range r.cfg.Pairs {
+		bi, qi := r.assetIndex(p.Base), r.assetIndex(p.Quote)
+		if bi < 0 || qi < 0 {
+			continue
+		}
+		base, quote := r.latestAssets[bi], r.latestAssets[qi]
+		if base.lastObserved == 0 || quote.lastObserved == 0 || base.failures > 0 || quote.failures > 0 {
+			continue
+		}
+		if r.breaker.isHalted(p.Base) || r.breaker.isHalted(p.Quote) {
+			continue
+		}
+
+		history := policy.History{Denom: p.Name(), LastRelay: base.lastRelay}
+		if quote.lastRelay.Before(history.LastRelay) {
+			history.LastRelay = quote.lastRelay
+		}
+		if base.lastPrice > 0 && quote.lastPrice > 0 {
+			history.LastPrice = base.lastPrice / quote.lastPrice
+		}
+
+		d := pairPolicy(p, r.cfg.Relayer).Decide(history, base.lastObserved/quote.lastObserved, now)
+		if !d.Relay {
+			continue
+		}
+		telemetry.IncrCounter(1, "pair", "trigger")
+		r.logger.Info().Str("pair", p.Name()).
+			Float64("last_ratio", history.LastPrice).
+			Float64("new_ratio", base.lastObserved/quote.lastObserved).
+			Str("reason", d.Reason).
+			Msg("pair relay triggered")
+		denoms = append(denoms, p.Base, p.Quote)
+	}
+	return denoms
+}
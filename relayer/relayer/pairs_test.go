@@ -0,0 +1,54 @@
+package relayer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/rs/zerolog"
+)
+
+func TestPairTriggers(t *testing.T) {
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, config.Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r.cfg = config.Config{
+		Relayer: config.Relayer{Interval: 24 * time.Hour, Deviation: 0.05},
+		Assets:  []config.Assets{{Denom: "WETH"}, {Denom: "ETH"}, {Denom: "BTC"}},
+		Pairs:   []config.Pair{{Base: "WETH", Quote: "ETH", Deviation: 0.01, Heartbeat: time.Hour}},
+	}
+
+	now := time.Now()
+	r.latestAssets = []asset{
+		{denom: "WETH", lastPrice: 3000, lastRelay: now, lastObserved: 3000},
+		{denom: "ETH", lastPrice: 3000, lastRelay: now, lastObserved: 3000},
+		{denom: "BTC", lastPrice: 60000, lastRelay: now, lastObserved: 60000},
+	}
+	if got := r.pairTriggers(now); len(got) != 0 {
+		t.Errorf("pairTriggers() = %v, want none", got)
+	}
+
+	// neither leg moved 5%, but the ratio moved 1.7%
+	r.latestAssets[0].lastObserved, r.latestAssets[1].lastObserved = 3030, 2980
+	if got, want := r.pairTriggers(now), []string{"WETH", "ETH"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pairTriggers() on deviation = %v, want %v", got, want)
+	}
+
+	// a single stale leg makes the pair stale
+	r.latestAssets[0].lastObserved, r.latestAssets[1].lastObserved = 3000, 3000
+	r.latestAssets[1].lastRelay = now.Add(-2 * time.Hour)
+	if got, want := r.pairTriggers(now), []string{"WETH", "ETH"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pairTriggers() on heartbeat = %v, want %v", got, want)
+	}
+
+	// pairs with a halted leg are skipped, even once the leg is observed
+	// again
+	r.halt("WETH", 3000, "test")
+	r.latestAssets[0].lastObserved = 3000
+	if got := r.pairTriggers(now); len(got) != 0 {
+		t.Errorf("pairTriggers() with halted leg = %v, want none", got)
+	}
+}
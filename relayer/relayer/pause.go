@@ -0,0 +1,29 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// fundsPause is how long relays are paused once the relayer account cannot
+// pay for them.
+const fundsPause = 5 * time.Minute
+
+// ErrPaused is returned when relaying while relays are paused.
+var ErrPaused = errors.New("relays are paused")
+
+// paused returns true if relays are paused as of now.
+func (r *Relayer) paused(now time.Time) bool {
+	return now.Before(r.pausedUntil)
+}
+
+// pauseRelays pauses the relays after the relayer account could not pay for
+// one, so that they are not broadcasted again until it is funded, and alerts.
+func (r *Relayer) pauseRelays(err error) {
+	r.pausedUntil = time.Now().Add(fundsPause)
+	r.alert(Alert{
+		Kind:    "funds",
+		Message: fmt.Sprintf("relays paused until %s: %v", r.pausedUntil.Format(time.RFC3339), err),
+	})
+}
@@ -0,0 +1,31 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/rs/zerolog"
+)
+
+func TestPauseRelays(t *testing.T) {
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, config.Config{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	r.pauseRelays(client.ErrInsufficientFunds)
+	if !r.paused(time.Now()) || r.paused(time.Now().Add(fundsPause)) {
+		t.Errorf("pauseRelays() paused until %s, want %s from now", r.pausedUntil, fundsPause)
+	}
+	if _, err := r.Relay(context.Background(), []string{"ETH"}); !errors.Is(err, ErrPaused) {
+		t.Errorf("Relay() while paused error = %v, want ErrPaused", err)
+	}
+	r.publishStatus()
+	if status := r.Status(); status.PausedUntil == nil || !status.PausedUntil.Equal(r.pausedUntil) {
+		t.Errorf("Status() paused until %v, want %s", status.PausedUntil, r.pausedUntil)
+	}
+}
@@ -0,0 +1,96 @@
+package relayer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/hashicorp/go-metrics"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+)
+
+// candidate is an asset which did not trigger a relay, but is close enough to
+// its threshold or its heartbeat to join one.
+type candidate struct {
+	denom string
+	// closeness ranks the candidates, from 0 for the farthest to 1 for an
+	// asset about to trigger.
+	closeness float64
+}
+
+// piggybackCandidate returns the given denom as a candidate if its price
+// deviated from its last relayed price by at least the piggyback fraction of
+// its threshold, or if its heartbeat is due within the piggyback window.
+func piggybackCandidate(
+	cfg config.Relayer,
+	denom string,
+	lastPrice, price float64,
+	d policy.Decision,
+	now time.Time,
+) (candidate, bool) {
+	c := candidate{denom: denom}
+	ok := false
+
+	if cfg.Piggyback > 0 && d.Threshold > 0 && lastPrice > 0 {
+		if closeness := policy.Change(lastPrice, price) / d.Threshold; closeness >= cfg.Piggyback {
+			c.closeness, ok = closeness, true
+		}
+	}
+	if cfg.PiggybackWindow > 0 && !d.Due.IsZero() {
+		if until := d.Due.Sub(now); until <= cfg.PiggybackWindow {
+			closeness := 1 - float64(until)/float64(cfg.PiggybackWindow)
+			if !ok || closeness > c.closeness {
+				c.closeness = closeness
+			}
+			ok = true
+		}
+	}
+	return c, ok
+}
+
+// piggyback returns the denoms of the candidates which are not in the batch
+// yet, the closest first.
+func piggyback(batch []string, candidates []candidate) []string {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].closeness > candidates[j].closeness
+	})
+
+	inBatch := make(map[string]bool, len(batch))
+	for _, denom := range batch {
+		inBatch[denom] = true
+	}
+
+	joined := []string{}
+	for _, c := range candidates {
+		if !inBatch[c.denom] {
+			joined = append(joined, c.denom)
+		}
+	}
+	return joined
+}
+
+// capBatch truncates the denoms joining a batch so that the batch has at most
+// maxBatch denoms, if non-zero.
+func capBatch(batch, joined []string, maxBatch int) []string {
+	if maxBatch <= 0 || len(batch)+len(joined) <= maxBatch {
+		return joined
+	}
+	if len(batch) >= maxBatch {
+		return nil
+	}
+	return joined[:maxBatch-len(batch)]
+}
+
+// recordPiggyback reports the assets which joined a relay, and the fees they
+// saved, assuming each would otherwise have paid the fee of a relay of its
+// own.
+func recordPiggyback(joined []string, fee float64, feeDenom string) {
+	if len(joined) == 0 {
+		return
+	}
+	telemetry.IncrCounter(float32(len(joined)), "piggyback", "assets")
+	telemetry.IncrCounterWithLabels([]string{"piggyback", "saved_fee"}, float32(fee*float64(len(joined))), []metrics.Label{
+		telemetry.NewLabel("denom", feeDenom),
+	})
+}
@@ -0,0 +1,52 @@
+package relayer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+)
+
+func TestPiggyback(t *testing.T) {
+	now := time.Now()
+	cfg := config.Relayer{Piggyback: 0.6, PiggybackWindow: time.Hour}
+
+	tests := []struct {
+		name  string
+		price float64
+		d     policy.Decision
+		want  bool
+	}{
+		{name: "near threshold", price: 103, d: policy.Decision{Threshold: 0.05}, want: true},
+		{name: "far from threshold", price: 102, d: policy.Decision{Threshold: 0.05}, want: false},
+		{name: "near heartbeat", price: 100, d: policy.Decision{Threshold: 0.05, Due: now.Add(30 * time.Minute)}, want: true},
+		{name: "far from heartbeat", price: 100, d: policy.Decision{Threshold: 0.05, Due: now.Add(2 * time.Hour)}, want: false},
+	}
+
+	candidates := []candidate{}
+	for _, tt := range tests {
+		c, ok := piggybackCandidate(cfg, tt.name, 100, tt.price, tt.d, now)
+		if ok != tt.want {
+			t.Errorf("piggybackCandidate(%s) = %v, want %v", tt.name, ok, tt.want)
+		}
+		if ok {
+			candidates = append(candidates, c)
+		}
+	}
+
+	joined := piggyback([]string{"BTC", "near heartbeat"}, candidates)
+	if want := []string{"near threshold"}; !reflect.DeepEqual(joined, want) {
+		t.Errorf("piggyback() = %v, want %v", joined, want)
+	}
+
+	// the closest candidates join first, up to the max batch size
+	joined = piggyback([]string{"BTC"}, []candidate{{"ETH", 0.7}, {"ATOM", 0.9}, {"OJO", 0.8}})
+	if got, want := capBatch([]string{"BTC"}, joined, 3), []string{"ATOM", "OJO"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("capBatch() = %v, want %v", got, want)
+	}
+	if got := capBatch([]string{"BTC", "ETH"}, joined, 2); len(got) != 0 {
+		t.Errorf("capBatch() = %v, want none", got)
+	}
+}
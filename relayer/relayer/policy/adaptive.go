@@ -0,0 +1,96 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+// Adaptive relays on deviations above a threshold that scales with the
+// realized volatility of the prices, between MinThreshold and MaxThreshold.
+// Once the price of a stable asset moves more than DepegBand away from its
+// Peg, the policy enters a stress mode using StressThreshold instead. It is
+// stateful and must only be used for a single asset.
+type Adaptive struct {
+	MinThreshold    float64
+	MaxThreshold    float64
+	Multiplier      float64
+	Window          time.Duration
+	Peg             float64
+	DepegBand       float64
+	StressThreshold float64
+
+	ring ring
+}
+
+func newAdaptive(cfg config.Policy, _ config.Relayer) (RelayPolicy, error) {
+	if cfg.MinThreshold <= 0 || cfg.MaxThreshold < cfg.MinThreshold {
+		return nil, fmt.Errorf("invalid threshold bounds [%v, %v]", cfg.MinThreshold, cfg.MaxThreshold)
+	}
+	if cfg.Window <= 0 {
+		return nil, fmt.Errorf("invalid volatility window %s", cfg.Window)
+	}
+
+	multiplier := cfg.VolatilityMultiplier
+	if multiplier == 0 {
+		multiplier = 1
+	}
+	stressThreshold := cfg.StressThreshold
+	if stressThreshold == 0 {
+		stressThreshold = cfg.MinThreshold
+	}
+
+	return &Adaptive{
+		MinThreshold:    cfg.MinThreshold,
+		MaxThreshold:    cfg.MaxThreshold,
+		Multiplier:      multiplier,
+		Window:          cfg.Window,
+		Peg:             cfg.Peg,
+		DepegBand:       cfg.DepegBand,
+		StressThreshold: stressThreshold,
+		ring:            newRing(defaultSamples),
+	}, nil
+}
+
+func (p *Adaptive) Decide(h History, price float64, now time.Time) Decision {
+	if p.ring.sampleDue(now, p.Window/time.Duration(len(p.ring.samples))) {
+		p.ring.add(sample{time: now, price: price})
+	}
+
+	threshold, stress := p.Threshold(now, price)
+	d := decideDeviation(h, price, threshold)
+	if stress {
+		d.Reason = fmt.Sprintf("stress mode, %v is off peg %v: %s", price, p.Peg, d.Reason)
+	}
+	return d
+}
+
+// Threshold returns the effective threshold as of now, and true in stress mode.
+func (p *Adaptive) Threshold(now time.Time, price float64) (float64, bool) {
+	if p.Peg > 0 && Change(p.Peg, price) > p.DepegBand {
+		return p.StressThreshold, true
+	}
+
+	threshold := p.Multiplier * p.Volatility(now)
+	return math.Min(math.Max(threshold, p.MinThreshold), p.MaxThreshold), false
+}
+
+// Volatility returns the realized volatility of the sampled prices over the
+// window ending now: the square root of the sum of the squared log returns
+// between consecutive samples.
+func (p *Adaptive) Volatility(now time.Time) float64 {
+	start := now.Add(-p.Window)
+
+	var sum float64
+	for i := 1; i < p.ring.size; i++ {
+		prev, cur := p.ring.at(i-1), p.ring.at(i)
+		if prev.time.Before(start) || prev.price <= 0 || cur.price <= 0 {
+			continue
+		}
+		r := math.Log(cur.price / prev.price)
+		sum += r * r
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,294 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/cron"
+)
+
+const (
+	TypeHeartbeat = "heartbeat"
+	TypeDeviation = "deviation"
+	TypeAdaptive  = "adaptive"
+	TypeAnd       = "and"
+	TypeOr        = "or"
+)
+
+type (
+	// History defines what the relayer remembers of an asset.
+	History struct {
+		Denom string
+		// LastPrice is the last relayed price, zero if never relayed.
+		LastPrice float64
+		// LastRelay is the time of the last relay, zero if never relayed.
+		LastRelay time.Time
+	}
+
+	// Decision defines the outcome of a relay policy.
+	Decision struct {
+		Relay bool
+		// Reason explains the decision in logs.
+		Reason string
+		// Threshold is the effective deviation threshold, zero if the
+		// policy has none.
+		Threshold float64
+		// Due is the time the policy relays at regardless of the price,
+		// zero if the policy has no heartbeat.
+		Due time.Time
+	}
+
+	// RelayPolicy decides whether an asset is relayed, given its history and
+	// its current price.
+	RelayPolicy interface {
+		Decide(h History, price float64, now time.Time) Decision
+	}
+
+	// Factory creates a policy from its config. Defaults holds the relayer
+	// section of the config, for policies falling back to its settings.
+	Factory func(cfg config.Policy, defaults config.Relayer) (RelayPolicy, error)
+)
+
+var (
+	factoriesMtx sync.RWMutex
+	factories    map[string]Factory
+)
+
+func init() {
+	// composite policies create their nested policies through New
+	factories = map[string]Factory{
+		TypeHeartbeat: newHeartbeat,
+		TypeDeviation: newDeviation,
+		TypeAdaptive:  newAdaptive,
+		TypeAnd:       newComposite,
+		TypeOr:        newComposite,
+	}
+}
+
+// Register makes a policy type available to the policy.type field of the
+// assets. It replaces the factory of a type that is already registered.
+func Register(policyType string, factory Factory) {
+	factoriesMtx.Lock()
+	defer factoriesMtx.Unlock()
+
+	factories[policyType] = factory
+}
+
+// New creates the policy of the given config. An empty config creates the
+// default policy.
+func New(cfg config.Policy, defaults config.Relayer) (RelayPolicy, error) {
+	if cfg.Type == "" {
+		return Default(defaults)
+	}
+
+	factoriesMtx.RLock()
+	factory, ok := factories[cfg.Type]
+	factoriesMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown policy type %q", cfg.Type)
+	}
+	return factory(cfg, defaults)
+}
+
+// NewPolicies creates the policies of the given assets, by denom, wrapped
+// with the smoothing of each asset.
+func NewPolicies(assets []config.Assets, defaults config.Relayer) (map[string]RelayPolicy, error) {
+	policies := make(map[string]RelayPolicy, len(assets))
+	for _, a := range assets {
+		p, err := New(a.Policy, defaults)
+		if err != nil {
+			return nil, fmt.Errorf("policy of %s: %w", a.Denom, err)
+		}
+		policies[a.Denom] = WithSmoothing(p, a.Smoothing)
+	}
+	return policies, nil
+}
+
+// Default returns the policy relaying on a heartbeat or a deviation, as
+// configured in the relayer section.
+func Default(defaults config.Relayer) (RelayPolicy, error) {
+	heartbeat, err := newHeartbeat(config.Policy{}, defaults)
+	if err != nil {
+		return nil, err
+	}
+	return Or{heartbeat, Deviation{Threshold: defaults.Deviation}}, nil
+}
+
+// Heartbeat relays when the interval elapsed since the last relay.
+type Heartbeat struct {
+	Interval time.Duration
+}
+
+func newHeartbeat(cfg config.Policy, defaults config.Relayer) (RelayPolicy, error) {
+	expr, jitter := cfg.Schedule, cfg.Jitter
+	if expr == "" && cfg.Interval == 0 {
+		expr = defaults.Schedule
+	}
+	if jitter == 0 {
+		jitter = defaults.Jitter
+	}
+	if expr != "" {
+		schedule, err := cron.Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		return Scheduled{Schedule: schedule, Jitter: jitter}, nil
+	}
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaults.Interval
+	}
+	return Heartbeat{Interval: interval}, nil
+}
+
+func (p Heartbeat) Decide(h History, _ float64, now time.Time) Decision {
+	due := h.LastRelay.Add(p.Interval)
+	since := now.Sub(h.LastRelay)
+	if since < p.Interval {
+		return Decision{Reason: fmt.Sprintf("heartbeat in %s", (p.Interval - since).Round(time.Second)), Due: due}
+	}
+	if h.LastRelay.IsZero() {
+		return Decision{Relay: true, Reason: "heartbeat: never relayed", Due: now}
+	}
+	return Decision{Relay: true, Reason: fmt.Sprintf("heartbeat: %s since last relay", since.Round(time.Second)), Due: due}
+}
+
+// Deviation relays when the price deviates from the last relayed price by
+// at least the threshold.
+type Deviation struct {
+	Threshold float64
+}
+
+func newDeviation(cfg config.Policy, defaults config.Relayer) (RelayPolicy, error) {
+	threshold := cfg.Threshold
+	if threshold == 0 {
+		threshold = defaults.Deviation
+	}
+	return Deviation{Threshold: threshold}, nil
+}
+
+func (p Deviation) Decide(h History, price float64, _ time.Time) Decision {
+	return decideDeviation(h, price, p.Threshold)
+}
+
+// decideDeviation relays when the price deviates from the last relayed price
+// by at least the threshold.
+func decideDeviation(h History, price, threshold float64) Decision {
+	if h.LastPrice == 0 {
+		return Decision{Reason: "deviation: no last price", Threshold: threshold}
+	}
+	change := Change(h.LastPrice, price)
+	if change < threshold {
+		return Decision{
+			Reason:    fmt.Sprintf("deviation of %.4f%% below %.4f%%", change*100, threshold*100),
+			Threshold: threshold,
+		}
+	}
+	return Decision{
+		Relay:     true,
+		Reason:    fmt.Sprintf("deviation: %.4f%% from %v to %v", change*100, h.LastPrice, price),
+		Threshold: threshold,
+	}
+}
+
+// Change returns the absolute relative change from the existing price to the
+// newest one, or zero if the existing price is zero.
+func Change(existing, newest float64) float64 {
+	if existing == 0 {
+		return 0
+	}
+
+	change := (newest - existing) / existing
+	if change < 0 {
+		change *= -1
+	}
+	return change
+}
+
+// And relays when every one of its policies relays.
+type And []RelayPolicy
+
+// Or relays when any of its policies relays.
+type Or []RelayPolicy
+
+func newComposite(cfg config.Policy, defaults config.Relayer) (RelayPolicy, error) {
+	policies := make([]RelayPolicy, len(cfg.Policies))
+	for i, nested := range cfg.Policies {
+		p, err := New(nested, defaults)
+		if err != nil {
+			return nil, err
+		}
+		policies[i] = p
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("%q policy without nested policies", cfg.Type)
+	}
+
+	if cfg.Type == TypeAnd {
+		return And(policies), nil
+	}
+	return Or(policies), nil
+}
+
+// Decide relays when every policy relays. Every policy is asked, so that
+// stateful policies observe every price. The effective threshold is the
+// largest threshold of the policies. It is only due when every policy
+// relays or is due, at the latest due time.
+func (ps And) Decide(h History, price float64, now time.Time) Decision {
+	relay, threshold := true, 0.0
+	due, dueAll := time.Time{}, true
+	relays, holds := []string{}, []string{}
+	for _, p := range ps {
+		d := p.Decide(h, price, now)
+		threshold = math.Max(threshold, d.Threshold)
+		if d.Due.After(due) {
+			due = d.Due
+		}
+		if d.Relay {
+			relays = append(relays, d.Reason)
+		} else {
+			relay = false
+			dueAll = dueAll && !d.Due.IsZero()
+			holds = append(holds, d.Reason)
+		}
+	}
+	if !dueAll {
+		due = time.Time{}
+	}
+	if relay {
+		return Decision{Relay: true, Reason: strings.Join(relays, " and "), Threshold: threshold, Due: due}
+	}
+	return Decision{Reason: strings.Join(holds, ", "), Threshold: threshold, Due: due}
+}
+
+// Decide relays when any policy relays. Every policy is asked, so that
+// stateful policies observe every price. The effective threshold is the
+// smallest non-zero threshold of the policies, and the due time the
+// earliest one.
+func (ps Or) Decide(h History, price float64, now time.Time) Decision {
+	threshold, due := 0.0, time.Time{}
+	relays, holds := []string{}, []string{}
+	for _, p := range ps {
+		d := p.Decide(h, price, now)
+		if d.Threshold > 0 && (threshold == 0 || d.Threshold < threshold) {
+			threshold = d.Threshold
+		}
+		if !d.Due.IsZero() && (due.IsZero() || d.Due.Before(due)) {
+			due = d.Due
+		}
+		if d.Relay {
+			relays = append(relays, d.Reason)
+		} else {
+			holds = append(holds, d.Reason)
+		}
+	}
+	if len(relays) > 0 {
+		return Decision{Relay: true, Reason: strings.Join(relays, " or "), Threshold: threshold, Due: due}
+	}
+	return Decision{Reason: strings.Join(holds, ", "), Threshold: threshold, Due: due}
+}
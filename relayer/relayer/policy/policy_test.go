@@ -0,0 +1,203 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+func TestDefault(t *testing.T) {
+	now := time.Now()
+	p, err := Default(config.Relayer{Interval: time.Hour, Deviation: 0.05})
+	if err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		history History
+		price   float64
+		want    bool
+	}{
+		{name: "never relayed", history: History{}, price: 100, want: true},
+		{name: "heartbeat", history: History{LastPrice: 100, LastRelay: now.Add(-time.Hour)}, price: 100, want: true},
+		{name: "deviation", history: History{LastPrice: 100, LastRelay: now}, price: 95, want: true},
+		{name: "no trigger", history: History{LastPrice: 100, LastRelay: now}, price: 104, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := p.Decide(tt.history, tt.price, now); d.Relay != tt.want {
+				t.Errorf("Decide() = %v, want relay %v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposite(t *testing.T) {
+	defaults := config.Relayer{Interval: 24 * time.Hour, Deviation: 0.05}
+	// relay on large deviations, or small ones once an hour
+	p, err := New(config.Policy{
+		Type: TypeOr,
+		Policies: []config.Policy{
+			{Type: TypeDeviation, Threshold: 0.1},
+			{Type: TypeAnd, Policies: []config.Policy{
+				{Type: TypeHeartbeat, Interval: time.Hour},
+				{Type: TypeDeviation, Threshold: 0.01},
+			}},
+		},
+	}, defaults)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	now := time.Now()
+	recent := History{LastPrice: 100, LastRelay: now.Add(-time.Minute)}
+	old := History{LastPrice: 100, LastRelay: now.Add(-2 * time.Hour)}
+
+	if d := p.Decide(recent, 102, now); d.Relay {
+		t.Errorf("Decide() small recent deviation = %v, want no relay", d)
+	}
+	if d := p.Decide(recent, 111, now); !d.Relay {
+		t.Errorf("Decide() large deviation = %v, want relay", d)
+	}
+	if d := p.Decide(old, 102, now); !d.Relay {
+		t.Errorf("Decide() small old deviation = %v, want relay", d)
+	}
+	if d := p.Decide(old, 100.5, now); d.Relay {
+		t.Errorf("Decide() no deviation = %v, want no relay", d)
+	}
+}
+
+type always struct{}
+
+func (always) Decide(History, float64, time.Time) Decision {
+	return Decision{Relay: true, Reason: "always"}
+}
+
+func TestRegister(t *testing.T) {
+	Register("always", func(config.Policy, config.Relayer) (RelayPolicy, error) { return always{}, nil })
+
+	policies, err := NewPolicies([]config.Assets{
+		{Denom: "BTC", Policy: config.Policy{Type: "always"}},
+		{Denom: "ETH"},
+	}, config.Relayer{Interval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewPolicies() error = %v", err)
+	}
+	if d := policies["BTC"].Decide(History{LastRelay: time.Now()}, 1, time.Now()); !d.Relay {
+		t.Errorf("Decide() custom policy = %v, want relay", d)
+	}
+
+	if _, err := New(config.Policy{Type: "unknown"}, config.Relayer{}); err == nil {
+		t.Errorf("New() expected error for unknown type")
+	}
+}
+
+func TestAdaptive(t *testing.T) {
+	p, err := New(config.Policy{
+		Type:         TypeAdaptive,
+		MinThreshold: 0.005,
+		MaxThreshold: 0.05,
+		Window:       time.Hour,
+	}, config.Relayer{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	adaptive := p.(*Adaptive)
+
+	start := time.Now()
+	h := History{LastPrice: 100, LastRelay: start}
+
+	// a calm market keeps the minimum threshold
+	d := p.Decide(h, 100, start)
+	if d.Threshold != 0.005 {
+		t.Errorf("Decide() calm threshold = %v, want %v", d.Threshold, 0.005)
+	}
+
+	// swings of 2% every sample raise the threshold up to the maximum
+	price := 100.0
+	for i := 1; i <= 20; i++ {
+		price *= 1 + 0.02*float64(1-2*(i%2))
+		d = p.Decide(h, price, start.Add(time.Duration(i)*time.Minute))
+	}
+	if d.Threshold != 0.05 {
+		t.Errorf("Decide() volatile threshold = %v, want %v", d.Threshold, 0.05)
+	}
+	if v := adaptive.Volatility(start.Add(20 * time.Minute)); v < 0.05 {
+		t.Errorf("Volatility() = %v, want above the maximum threshold", v)
+	}
+}
+
+func TestAdaptiveStress(t *testing.T) {
+	p, err := New(config.Policy{
+		Type:            TypeAdaptive,
+		MinThreshold:    0.01,
+		MaxThreshold:    0.05,
+		Window:          time.Hour,
+		Peg:             1,
+		DepegBand:       0.005,
+		StressThreshold: 0.001,
+	}, config.Relayer{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	now := time.Now()
+	h := History{LastPrice: 0.99, LastRelay: now}
+	d := p.Decide(h, 0.9885, now)
+	if d.Threshold != 0.001 || !d.Relay {
+		t.Errorf("Decide() off peg = %v, want a relay with the stress threshold", d)
+	}
+	h.LastPrice = 1
+	if d := p.Decide(h, 0.998, now.Add(time.Minute)); d.Threshold == 0.001 {
+		t.Errorf("Decide() within the band = %v, want no stress mode", d)
+	}
+}
+
+func TestScheduled(t *testing.T) {
+	p, err := New(config.Policy{Type: TypeHeartbeat}, config.Relayer{
+		Interval: 24 * time.Hour,
+		Schedule: "0 * * * *",
+		Jitter:   time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// assets last relayed at different times are due at the same time
+	at := func(hour, min int) time.Time { return time.Date(2024, 1, 1, hour, min, 0, 0, time.UTC) }
+	early := History{LastPrice: 100, LastRelay: at(10, 1)}
+	late := History{LastPrice: 100, LastRelay: at(10, 58)}
+
+	if d := p.Decide(early, 100, at(10, 59)); d.Relay {
+		t.Errorf("Decide() before schedule = %v, want no relay", d)
+	}
+	for now := at(11, 0); now.Before(at(11, 2)); now = now.Add(time.Second) {
+		relayEarly, relayLate := p.Decide(early, 100, now).Relay, p.Decide(late, 100, now).Relay
+		if relayEarly != relayLate {
+			t.Fatalf("Decide() at %v = %v and %v, want the same", now, relayEarly, relayLate)
+		}
+	}
+	if d := p.Decide(late, 100, at(11, 1)); !d.Relay {
+		t.Errorf("Decide() after jitter = %v, want relay", d)
+	}
+}
+
+func TestDue(t *testing.T) {
+	now := time.Now()
+	h := History{LastPrice: 100, LastRelay: now.Add(-30 * time.Minute)}
+	hourly, daily := Heartbeat{Interval: time.Hour}, Heartbeat{Interval: 24 * time.Hour}
+
+	if d := (Or{daily, hourly, Deviation{Threshold: 0.05}}).Decide(h, 100, now); !d.Due.Equal(h.LastRelay.Add(time.Hour)) {
+		t.Errorf("Or.Decide() due = %v, want the earliest heartbeat", d.Due)
+	}
+	if d := (And{hourly, daily}).Decide(h, 100, now); !d.Due.Equal(h.LastRelay.Add(24 * time.Hour)) {
+		t.Errorf("And.Decide() due = %v, want the latest heartbeat", d.Due)
+	}
+	// the deviation may never trigger
+	if d := (And{hourly, Deviation{Threshold: 0.05}}).Decide(h, 100, now); !d.Due.IsZero() {
+		t.Errorf("And.Decide() due = %v, want none", d.Due)
+	}
+}
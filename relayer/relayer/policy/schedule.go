@@ -0,0 +1,51 @@
+package policy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/relayer/cron"
+)
+
+// jitterSeed differs between relayer processes, so that operators sharing a
+// schedule spread their heartbeats, while the assets of a process sharing a
+// schedule are delayed alike and coalesced into a single relay.
+var jitterSeed = rand.Uint64()
+
+// Scheduled relays at the first occurrence of its schedule after the last
+// relay, delayed by up to Jitter. Assets sharing a schedule and a jitter are
+// due at the same time, whenever they were last relayed.
+type Scheduled struct {
+	Schedule *cron.Schedule
+	Jitter   time.Duration
+}
+
+func (p Scheduled) Decide(h History, _ float64, now time.Time) Decision {
+	if h.LastRelay.IsZero() {
+		return Decision{Relay: true, Reason: "heartbeat: never relayed", Due: now}
+	}
+
+	due := p.Schedule.Next(h.LastRelay)
+	at := due.Add(p.delay(due))
+	if now.Before(at) {
+		return Decision{Reason: fmt.Sprintf("heartbeat at %s", at.Format(time.RFC3339)), Due: at}
+	}
+	return Decision{Relay: true, Reason: fmt.Sprintf("heartbeat: scheduled at %s", due.Format(time.RFC3339)), Due: at}
+}
+
+// delay returns the jitter of the occurrence of the schedule at the given
+// time, the same for every asset of the process.
+func (p Scheduled) delay(at time.Time) time.Duration {
+	if p.Jitter <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_ = binary.Write(h, binary.BigEndian, jitterSeed)
+	_ = binary.Write(h, binary.BigEndian, at.Unix())
+	h.Write([]byte(p.Schedule.String()))
+	return time.Duration(h.Sum64() % uint64(p.Jitter))
+}
@@ -0,0 +1,197 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+const (
+	SmoothingEMA  = "ema"
+	SmoothingTWAP = "twap"
+
+	defaultSamples = 120
+)
+
+// Smoother smooths a series of observed prices.
+type Smoother interface {
+	// Add observes a price and returns the smoothed price as of now.
+	Add(now time.Time, price float64) float64
+}
+
+// WithSmoothing wraps a policy so that it decides on smoothed prices, and
+// only relays once it decided to relay on the configured number of
+// consecutive ticks. The returned policy is stateful and must only be used
+// for a single asset.
+func WithSmoothing(p RelayPolicy, cfg config.Smoothing) RelayPolicy {
+	if cfg.Type == "" && cfg.Confirmations <= 1 {
+		return p
+	}
+
+	s := &Smoothed{Policy: p, Confirmations: cfg.Confirmations}
+	switch cfg.Type {
+	case SmoothingEMA:
+		s.Smoother = &EMA{Window: cfg.Window}
+	case SmoothingTWAP:
+		s.Smoother = NewTWAP(cfg.Window, cfg.Samples)
+	}
+	return s
+}
+
+// Smoothed decides on the prices of its smoother, if any, and requires
+// Confirmations consecutive relay decisions before relaying.
+type Smoothed struct {
+	Policy        RelayPolicy
+	Smoother      Smoother
+	Confirmations int
+
+	streak int
+}
+
+func (s *Smoothed) Decide(h History, price float64, now time.Time) Decision {
+	smoothed := price
+	if s.Smoother != nil {
+		smoothed = s.Smoother.Add(now, price)
+	}
+
+	d := s.Policy.Decide(h, smoothed, now)
+	if s.Smoother != nil {
+		d.Reason = fmt.Sprintf("%s (smoothed price %v, observed %v)", d.Reason, smoothed, price)
+	}
+	if !d.Relay {
+		s.streak = 0
+		return d
+	}
+
+	s.streak++
+	if s.streak < s.Confirmations {
+		return Decision{
+			Reason:    fmt.Sprintf("%s; confirmation %d of %d", d.Reason, s.streak, s.Confirmations),
+			Threshold: d.Threshold,
+			Due:       d.Due,
+		}
+	}
+	// the next relay needs new confirmations
+	s.streak = 0
+	return d
+}
+
+// EMA is an exponential moving average whose weights decay with the time
+// between observations, with a time constant of Window.
+type EMA struct {
+	Window time.Duration
+
+	value float64
+	last  time.Time
+}
+
+func (e *EMA) Add(now time.Time, price float64) float64 {
+	if e.last.IsZero() || e.Window <= 0 {
+		e.value, e.last = price, now
+		return e.value
+	}
+
+	dt := now.Sub(e.last)
+	if dt > 0 {
+		alpha := 1 - math.Exp(-float64(dt)/float64(e.Window))
+		e.value += alpha * (price - e.value)
+		e.last = now
+	}
+	return e.value
+}
+
+type sample struct {
+	time  time.Time
+	price float64
+}
+
+// ring is a ring buffer of samples, overwriting the oldest sample when full.
+type ring struct {
+	samples []sample
+	next    int // index of the next sample to write
+	size    int
+}
+
+func newRing(capacity int) ring {
+	return ring{samples: make([]sample, capacity)}
+}
+
+func (r *ring) add(s sample) {
+	r.samples[r.next] = s
+	r.next = (r.next + 1) % len(r.samples)
+	if r.size < len(r.samples) {
+		r.size++
+	}
+}
+
+// at returns the i-th oldest sample.
+func (r *ring) at(i int) sample {
+	return r.samples[(r.next-r.size+i+len(r.samples))%len(r.samples)]
+}
+
+// sampleDue returns true if a sample taken now is at least interval after
+// the latest sample.
+func (r *ring) sampleDue(now time.Time, interval time.Duration) bool {
+	return r.size == 0 || now.Sub(r.at(r.size-1).time) >= interval
+}
+
+// TWAP is a time-weighted average price over Window. Prices are kept in a
+// ring buffer and sampled at most once every Window / samples, so that the
+// buffer always covers the window; prices observed in between are ignored.
+type TWAP struct {
+	Window time.Duration
+
+	ring   ring
+	latest float64 // latest observed price, sampled or not
+}
+
+// NewTWAP returns a TWAP over the given window, keeping at most the given
+// number of samples, or a default number if zero.
+func NewTWAP(window time.Duration, samples int) *TWAP {
+	if samples <= 0 {
+		samples = defaultSamples
+	}
+	return &TWAP{Window: window, ring: newRing(samples)}
+}
+
+func (t *TWAP) Add(now time.Time, price float64) float64 {
+	t.latest = price
+	if t.ring.sampleDue(now, t.Window/time.Duration(len(t.ring.samples))) {
+		t.ring.add(sample{time: now, price: price})
+	}
+	return t.average(now)
+}
+
+// average returns the average of the sampled prices over the window ending
+// now, each weighted by how long it was the latest sample.
+func (t *TWAP) average(now time.Time) float64 {
+	start := now.Add(-t.Window)
+
+	var weighted, total float64
+	for i := 0; i < t.ring.size; i++ {
+		s := t.ring.at(i)
+		end := now
+		if i+1 < t.ring.size {
+			end = t.ring.at(i + 1).time
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		from := s.time
+		if from.Before(start) {
+			from = start
+		}
+
+		w := float64(end.Sub(from))
+		weighted += w * s.price
+		total += w
+	}
+
+	if total == 0 {
+		return t.latest
+	}
+	return weighted / total
+}
@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+func TestEMA(t *testing.T) {
+	start := time.Now()
+	e := &EMA{Window: time.Minute}
+
+	if got := e.Add(start, 100); got != 100 {
+		t.Errorf("Add() first = %v, want 100", got)
+	}
+	// after one time constant, the average moved by 1 - 1/e of the change
+	got := e.Add(start.Add(time.Minute), 200)
+	want := 100 + 100*(1-math.Exp(-1))
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Add() = %v, want %v", got, want)
+	}
+}
+
+func TestTWAP(t *testing.T) {
+	start := time.Now()
+	twap := NewTWAP(time.Minute, 7)
+
+	// 100 for 50s, then a 10s spike to 160
+	for i := 0; i < 5; i++ {
+		twap.Add(start.Add(time.Duration(i)*10*time.Second), 100)
+	}
+	twap.Add(start.Add(50*time.Second), 160)
+	got := twap.Add(start.Add(60*time.Second), 160)
+	if want := 110.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Add() = %v, want %v", got, want)
+	}
+
+	// prices observed between samples are ignored
+	if got := twap.Add(start.Add(61*time.Second), 1000); got > 200 {
+		t.Errorf("Add() between samples = %v, want the spike ignored", got)
+	}
+
+	// older samples are out of the window: the last sample before it, 160,
+	// was the latest one over the whole window, and the new one has no
+	// weight yet
+	got = twap.Add(start.Add(10*time.Minute), 120)
+	if want := 160.0; got != want {
+		t.Errorf("Add() after the window = %v, want %v", got, want)
+	}
+}
+
+func TestSmoothedConfirmations(t *testing.T) {
+	p := WithSmoothing(Deviation{Threshold: 0.05}, config.Smoothing{Confirmations: 3})
+	h := History{LastPrice: 100, LastRelay: time.Now()}
+	now := time.Now()
+
+	for i := 1; i <= 2; i++ {
+		if d := p.Decide(h, 110, now); d.Relay {
+			t.Fatalf("Decide() tick %d = %v, want pending confirmation", i, d)
+		}
+	}
+	if d := p.Decide(h, 110, now); !d.Relay {
+		t.Errorf("Decide() third tick = %v, want relay", d)
+	}
+
+	// an interrupted streak starts over
+	p.Decide(h, 110, now)
+	p.Decide(h, 101, now)
+	if d := p.Decide(h, 110, now); d.Relay {
+		t.Errorf("Decide() after an interrupted streak = %v, want pending confirmation", d)
+	}
+}
@@ -0,0 +1,161 @@
+package quorum
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/hashicorp/go-metrics"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/rs/zerolog"
+)
+
+// ErrNoQuorum is returned when not enough nodes agree on a price.
+var ErrNoQuorum = errors.New("no quorum")
+
+// Reading defines the price read from a node, or the error of the read.
+type Reading struct {
+	Endpoint string
+	Price    float64
+	Err      error
+}
+
+// Reader reads prices from several Ojo nodes at the same height and only
+// returns a price most of them agree on.
+type Reader struct {
+	logger    zerolog.Logger
+	endpoints []string
+	minAgree  int
+	tolerance float64
+	timeout   time.Duration
+}
+
+// NewReader returns a quorum reader of the given config, or nil if the
+// config has no endpoints.
+func NewReader(logger zerolog.Logger, cfg config.Quorum, timeout time.Duration) *Reader {
+	if len(cfg.GRPCEndpoints) == 0 {
+		return nil
+	}
+	return &Reader{
+		logger:    logger.With().Str("module", "quorum").Logger(),
+		endpoints: cfg.GRPCEndpoints,
+		minAgree:  cfg.MinAgree,
+		tolerance: cfg.Tolerance,
+		timeout:   timeout,
+	}
+}
+
+// Price reads the price of a denom from every node at the given height and
+// returns the median of the largest group of agreeing prices. It fails with
+// ErrNoQuorum if the group is smaller than the minimum agreement, wrapping
+// the errors of the nodes if every read failed. Nodes outside of the group
+// are logged and counted as disagreeing.
+func (q *Reader) Price(ctx context.Context, denom string, height int64) (float64, error) {
+	readings := make([]Reading, len(q.endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range q.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+
+			readings[i] = Reading{Endpoint: endpoint}
+			price, err := client.QueryExchangeRate(ctx, endpoint, q.timeout, denom, height)
+			if err != nil {
+				readings[i].Err = err
+				return
+			}
+			readings[i].Price, readings[i].Err = price.Amount.Float64()
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	price, agreeing := Agree(readings, q.tolerance)
+	for i, r := range readings {
+		if agreeing[i] {
+			continue
+		}
+		telemetry.IncrCounterWithLabels([]string{"quorum", "disagree"}, 1, []metrics.Label{
+			telemetry.NewLabel("endpoint", r.Endpoint),
+		})
+		event := q.logger.Warn().Str("denom", denom).Int64("height", height).Str("endpoint", r.Endpoint)
+		if r.Err != nil {
+			event.Err(r.Err).Msg("node unavailable for quorum read")
+		} else {
+			event.Float64("price", r.Price).Float64("agreed_price", price).Msg("node disagrees with quorum")
+		}
+	}
+
+	agree := 0
+	for _, ok := range agreeing {
+		if ok {
+			agree++
+		}
+	}
+	telemetry.SetGaugeWithLabels([]string{"quorum", "agree"}, float32(agree), []metrics.Label{
+		telemetry.NewLabel("denom", denom),
+	})
+	if agree < q.minAgree {
+		err := fmt.Errorf("%w for %s at height %d: %d of %d nodes agree, %d required",
+			ErrNoQuorum, denom, height, agree, len(readings), q.minAgree)
+		// e.g. ErrPriceNotFound from every node
+		errs := []error{}
+		for _, r := range readings {
+			if r.Err != nil {
+				errs = append(errs, r.Err)
+			}
+		}
+		if len(errs) == len(readings) {
+			err = fmt.Errorf("%w: %w", err, errors.Join(errs...))
+		}
+		return 0, err
+	}
+	return price, nil
+}
+
+// Agree returns the median price of the largest group of readings that are
+// within the tolerance of each other, and which readings are in that group.
+// Failed reads and non positive prices never agree.
+func Agree(readings []Reading, tolerance float64) (float64, []bool) {
+	agreeing := make([]bool, len(readings))
+
+	prices := []float64{}
+	for _, r := range readings {
+		if r.Err == nil && r.Price > 0 {
+			prices = append(prices, r.Price)
+		}
+	}
+	if len(prices) == 0 {
+		return 0, agreeing
+	}
+	sort.Float64s(prices)
+
+	// sliding window over the sorted prices: the widest window whose ends
+	// are within the tolerance of each other
+	best, bestLo, bestHi := 0, 0, 0
+	lo := 0
+	for hi := range prices {
+		for (prices[hi]-prices[lo])/prices[lo] > tolerance {
+			lo++
+		}
+		if hi-lo+1 > best {
+			best, bestLo, bestHi = hi-lo+1, lo, hi
+		}
+	}
+
+	group := prices[bestLo : bestHi+1]
+	median := group[len(group)/2]
+	if len(group)%2 == 0 {
+		median = (group[len(group)/2-1] + group[len(group)/2]) / 2
+	}
+
+	for i, r := range readings {
+		agreeing[i] = r.Err == nil && r.Price >= group[0] && r.Price <= group[len(group)-1]
+	}
+	return median, agreeing
+}
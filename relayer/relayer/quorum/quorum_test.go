@@ -0,0 +1,57 @@
+package quorum
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAgree(t *testing.T) {
+	unavailable := errors.New("unavailable")
+
+	tests := []struct {
+		name      string
+		readings  []Reading
+		wantPrice float64
+		wantAgree []bool
+	}{
+		{
+			name:      "all agree",
+			readings:  []Reading{{Price: 100}, {Price: 100.05}, {Price: 99.98}},
+			wantPrice: 100,
+			wantAgree: []bool{true, true, true},
+		},
+		{
+			name:      "one outlier",
+			readings:  []Reading{{Price: 100}, {Price: 150}, {Price: 100.0625}},
+			wantPrice: 100.03125,
+			wantAgree: []bool{true, false, true},
+		},
+		{
+			name:      "one unavailable",
+			readings:  []Reading{{Price: 100}, {Err: unavailable}, {Price: 0}},
+			wantPrice: 100,
+			wantAgree: []bool{true, false, false},
+		},
+		{
+			name:      "none available",
+			readings:  []Reading{{Err: unavailable}, {Err: unavailable}},
+			wantPrice: 0,
+			wantAgree: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, agreeing := Agree(tt.readings, 0.001)
+			if price != tt.wantPrice {
+				t.Errorf("Agree() price = %v, want %v", price, tt.wantPrice)
+			}
+			for i := range tt.wantAgree {
+				if agreeing[i] != tt.wantAgree[i] {
+					t.Errorf("Agree() agreeing = %v, want %v", agreeing, tt.wantAgree)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package reference
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+const (
+	TypeOjo  = "ojo"
+	TypeHTTP = "http"
+
+	defaultTimeout = 10 * time.Second
+)
+
+type (
+	// Source defines an independent source of reference prices.
+	Source interface {
+		// Name identifies the source in logs and alerts.
+		Name() string
+		// Price returns the reference price of the given denom.
+		Price(ctx context.Context, denom string) (float64, error)
+	}
+
+	// Factory creates a source from its config.
+	Factory func(cfg config.Reference) (Source, error)
+)
+
+var (
+	factoriesMtx sync.RWMutex
+	factories    = map[string]Factory{
+		TypeOjo:  newOjoSource,
+		TypeHTTP: newHTTPSource,
+	}
+)
+
+// Register makes a source type available to the reference.type field of the
+// assets. It replaces the factory of a type that is already registered.
+func Register(sourceType string, factory Factory) {
+	factoriesMtx.Lock()
+	defer factoriesMtx.Unlock()
+
+	factories[sourceType] = factory
+}
+
+// New creates the source of the given config.
+func New(cfg config.Reference) (Source, error) {
+	factoriesMtx.RLock()
+	factory, ok := factories[cfg.Type]
+	factoriesMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown reference type %q", cfg.Type)
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return factory(cfg)
+}
+
+// NewSources creates the reference sources of the given assets, by denom.
+// Assets without a reference are skipped.
+func NewSources(assets []config.Assets) (map[string]Source, error) {
+	sources := map[string]Source{}
+	for _, a := range assets {
+		if a.Reference.Type == "" {
+			continue
+		}
+		source, err := New(a.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("reference of %s: %w", a.Denom, err)
+		}
+		sources[a.Denom] = source
+	}
+	return sources, nil
+}
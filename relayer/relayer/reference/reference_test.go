@@ -0,0 +1,79 @@
+package reference
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+func TestHTTPSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTC" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"prices":[{"value":"64000.5"},{"value":1}]}}`))
+	}))
+	defer srv.Close()
+
+	source, err := New(config.Reference{
+		Type:     TypeHTTP,
+		URL:      srv.URL + "/price?symbol={denom}",
+		JSONPath: "data.prices.0.value",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	price, err := source.Price(context.Background(), "BTC")
+	if err != nil {
+		t.Fatalf("Price() error = %v", err)
+	}
+	if price != 64000.5 {
+		t.Errorf("Price() = %v, want %v", price, 64000.5)
+	}
+
+	if _, err := source.Price(context.Background(), "ETH"); err == nil {
+		t.Errorf("Price() expected error for unknown denom")
+	}
+}
+
+func TestLookupNumber(t *testing.T) {
+	body := map[string]any{"a": []any{map[string]any{"b": "x"}}}
+	for _, path := range []string{"a.0.b", "a.1.b", "a.b", "c"} {
+		if _, err := lookupNumber(body, path); err == nil {
+			t.Errorf("lookupNumber(%q) expected error", path)
+		}
+	}
+}
+
+type fixedSource float64
+
+func (s fixedSource) Name() string { return "fixed" }
+
+func (s fixedSource) Price(context.Context, string) (float64, error) { return float64(s), nil }
+
+func TestRegister(t *testing.T) {
+	Register("fixed", func(config.Reference) (Source, error) { return fixedSource(42), nil })
+
+	sources, err := NewSources([]config.Assets{
+		{Denom: "BTC", Reference: config.Reference{Type: "fixed"}},
+		{Denom: "ETH"},
+	})
+	if err != nil {
+		t.Fatalf("NewSources() error = %v", err)
+	}
+	if len(sources) != 1 {
+		t.Fatalf("NewSources() = %v, want only BTC", sources)
+	}
+	if price, _ := sources["BTC"].Price(context.Background(), "BTC"); price != 42 {
+		t.Errorf("Price() = %v, want 42", price)
+	}
+
+	if _, err := New(config.Reference{Type: "unknown"}); err == nil {
+		t.Errorf("New() expected error for unknown type")
+	}
+}
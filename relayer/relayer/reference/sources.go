@@ -0,0 +1,125 @@
+package reference
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+)
+
+// maxResponseSize is the largest HTTP response read from a reference source.
+const maxResponseSize = 1 << 20
+
+// ojoSource reads the exchange rates of a second Ojo node.
+type ojoSource struct {
+	endpoint string
+	timeout  time.Duration
+}
+
+func newOjoSource(cfg config.Reference) (Source, error) {
+	if cfg.GRPCEndpoint == "" {
+		return nil, fmt.Errorf("missing grpc_endpoint")
+	}
+	return ojoSource{endpoint: cfg.GRPCEndpoint, timeout: cfg.Timeout}, nil
+}
+
+func (s ojoSource) Name() string {
+	return "ojo:" + s.endpoint
+}
+
+func (s ojoSource) Price(ctx context.Context, denom string) (float64, error) {
+	price, err := client.QueryExchangeRate(ctx, s.endpoint, s.timeout, denom, 0)
+	if err != nil {
+		return 0, err
+	}
+	return price.Amount.Float64()
+}
+
+// httpSource reads a price from the JSON response of an HTTP endpoint.
+type httpSource struct {
+	url      string
+	jsonPath string
+	client   *http.Client
+}
+
+func newHTTPSource(cfg config.Reference) (Source, error) {
+	if cfg.URL == "" || cfg.JSONPath == "" {
+		return nil, fmt.Errorf("missing url or json_path")
+	}
+	return httpSource{
+		url:      cfg.URL,
+		jsonPath: cfg.JSONPath,
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (s httpSource) Name() string {
+	return "http:" + s.url
+}
+
+func (s httpSource) Price(ctx context.Context, denom string) (float64, error) {
+	url := strings.ReplaceAll(s.url, "{denom}", denom)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s responded with %s", url, resp.Status)
+	}
+
+	var body any
+	dec := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return 0, err
+	}
+	return lookupNumber(body, s.jsonPath)
+}
+
+// lookupNumber returns the number at the given dot separated path of a
+// decoded JSON value, e.g. "data.prices.0.value". The number may be encoded
+// as a string.
+func lookupNumber(v any, path string) (float64, error) {
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			child, ok := node[key]
+			if !ok {
+				return 0, fmt.Errorf("no %q in %q", key, path)
+			}
+			v = child
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return 0, fmt.Errorf("invalid index %q in %q", key, path)
+			}
+			v = node[i]
+		default:
+			return 0, fmt.Errorf("no %q in %q", key, path)
+		}
+	}
+
+	switch n := v.(type) {
+	case json.Number:
+		return n.Float64()
+	case string:
+		return strconv.ParseFloat(n, 64)
+	default:
+		return 0, fmt.Errorf("value at %q is not a number", path)
+	}
+}
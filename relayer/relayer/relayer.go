@@ -2,13 +2,26 @@ package relayer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/hashicorp/go-metrics"
 	"github.com/ojo-network/ojo-evm/relayer/config"
 	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/composed"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/election"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/lightclient"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/quorum"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/reference"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/retry"
 	gmptypes "github.com/ojo-network/ojo/x/gmp/types"
 	pfsync "github.com/ojo-network/price-feeder/pkg/sync"
 	"github.com/rs/zerolog"
@@ -17,26 +30,57 @@ import (
 )
 
 const (
-	tickerSleep      = 500 * time.Millisecond
-	broadcastTimeout = 5 // how many blocks to wait for a tx to be included
+	tickerSleep = 500 * time.Millisecond
+	// how long in-flight broadcasts may run after a shutdown is requested
+	shutdownTimeout = 30 * time.Second
 )
 
 type asset struct {
 	lastPrice float64
 	lastRelay time.Time
 	denom     string
+	// lastObserved is the price seen on the previous tick, zero if unknown
+	lastObserved float64
+	// referenceMismatch is true while the price disagrees with its reference
+	referenceMismatch bool
+	// threshold is the effective deviation threshold of the relay policy
+	threshold float64
+	// lastRate is the rate of the composed feed as of the last relay
+	lastRate float64
+	// failures counts the consecutive failed price reads, retried from
+	// retryAt on
+	failures  int
+	retryAt   time.Time
+	lastError error
 }
 
 // Relayer defines a structure that interfaces with the Ojo node.
 type Relayer struct {
 	logger      zerolog.Logger
 	closer      *pfsync.Closer
+	stopped     *pfsync.Closer
+	running     atomic.Bool
 	ChainHeight *client.ChainHeight
 
 	relayerClient client.RelayerClient
 	cfg           config.Config
-
-	latestAssets []asset // latest price and relay time
+	reloads       chan config.Config
+	elector       election.Elector
+	leader        bool
+	breaker       *breaker
+	references    map[string]reference.Source   // reference sources by denom
+	quorum        *quorum.Reader                // nil unless quorum reads are configured
+	policies      map[string]policy.RelayPolicy // relay policies by denom
+	verifier      *lightclient.Verifier         // created on first use with a light client
+	feeds         map[string]*composed.Feed     // composed feeds by denom
+	resolveWindow time.Duration                 // of the destination contract, zero if unknown
+	pausedUntil   time.Time                     // relays are paused until then, e.g. without funds
+
+	latestAssets []asset     // latest price and relay time
+	window       batchWindow // denoms waiting for other triggers
+
+	statusMtx sync.Mutex
+	status    Status // snapshot of the state, published after every tick
 }
 
 func New(
@@ -44,106 +88,377 @@ func New(
 	relayerClient client.RelayerClient,
 	cfg config.Config,
 ) (*Relayer, error) {
+	elector, err := election.NewElector(cfg.Election, cfg.Relayer.Interval, relayerClient)
+	if err != nil {
+		return nil, err
+	}
+	references, err := reference.NewSources(cfg.Assets)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := policy.NewPolicies(cfg.Assets, cfg.Relayer)
+	if err != nil {
+		return nil, err
+	}
+	feeds, err := composed.NewFeeds(cfg.Assets, cfg.Relayer.EVMRPC, relayerClient.RPCTimeout)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Relayer{
 		relayerClient: relayerClient,
 		cfg:           cfg,
 		logger:        logger.With().Str("module", "relayer").Logger(),
 		closer:        pfsync.NewCloser(),
+		stopped:       pfsync.NewCloser(),
+		reloads:       make(chan config.Config, 1),
+		elector:       elector,
+		breaker:       newBreaker(),
+		references:    references,
+		policies:      policies,
+		feeds:         feeds,
+		quorum:        quorum.NewReader(logger, cfg.Quorum, relayerClient.RPCTimeout),
 		latestAssets:  []asset{},
 	}, nil
 }
 
-// Start starts the relayer process in a blocking fashion.
+// Start starts the relayer process in a blocking fashion. It returns once
+// the context is done or Stop is called, after the in-flight tick finished
+// or the shutdown timeout elapsed.
 func (r *Relayer) Start(ctx context.Context) error {
+	r.running.Store(true)
+	defer r.stopped.Close()
+
+	// refuse to start with heartbeats letting the destination go stale
+	if err := r.initFreshness(ctx); err != nil {
+		return err
+	}
+
+	// in-flight broadcasts may outlive the cancellation of ctx, up to the
+	// shutdown timeout
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
+	shutdownTimer := make(chan *time.Timer, 1)
+	stopWork := context.AfterFunc(ctx, func() {
+		shutdownTimer <- time.AfterFunc(shutdownTimeout, cancelWork)
+	})
+	defer func() {
+		// once started, the timer is sent right away
+		if !stopWork() {
+			(<-shutdownTimer).Stop()
+		}
+	}()
+
+	defer r.shutdown()
+
+	// consecutive failed ticks back off as failed broadcasts do
+	failures := 0
 	for {
 		select {
 		case <-ctx.Done():
-			r.closer.Close()
+			r.logger.Info().Msg("stopping relayer")
+			return nil
 
-		default:
-			r.logger.Debug().Msg("starting relayer tick")
+		case <-r.closer.Done():
+			r.logger.Info().Msg("stopping relayer")
+			return nil
+
+		case cfg := <-r.reloads:
+			r.applyConfig(cfg)
 
-			startTime := time.Now()
+		default:
+			// only the leader relays
+			if r.isLeader(workCtx) {
+				r.logger.Debug().Msg("starting relayer tick")
+
+				startTime := time.Now()
+
+				if err := r.tick(workCtx); err != nil {
+					failures++
+					telemetry.IncrCounter(1, "failure", "tick")
+					r.logger.Err(err).Int("failures", failures).Msg("relayer tick failed")
+				} else {
+					failures = 0
+				}
+
+				telemetry.MeasureSince(startTime, "runtime", "tick")
+				telemetry.IncrCounter(1, "new", "tick")
+			}
+			r.publishStatus()
 
-			if err := r.tick(ctx); err != nil {
-				telemetry.IncrCounter(1, "failure", "tick")
-				r.logger.Err(err).Msg("relayer tick failed")
+			sleep := tickerSleep
+			if failures > 0 {
+				sleep = max(sleep, retry.Backoff(r.cfg.Retry.Broadcast, failures))
 			}
+			select {
+			case <-ctx.Done():
+			case <-r.closer.Done():
+			case <-time.After(sleep):
+			}
+		}
+	}
+}
 
-			telemetry.MeasureSince(startTime, "runtime", "tick")
-			telemetry.IncrCounter(1, "new", "tick")
+// shutdown gives up the leadership, closes the chain height subscription and
+// flushes the memory of the relayer to the logs.
+func (r *Relayer) shutdown() {
+	if err := r.elector.Close(); err != nil {
+		r.logger.Err(err).Msg("unable to give up leadership")
+	}
+	r.closeVerifier()
 
-			time.Sleep(tickerSleep)
+	if r.relayerClient.ChainHeight != nil {
+		r.relayerClient.ChainHeight.Close()
+		select {
+		case <-r.relayerClient.ChainHeight.Done():
+		case <-time.After(shutdownTimeout):
+			r.logger.Warn().Msg("timed out closing the chain height subscription")
 		}
 	}
+
+	for _, a := range r.latestAssets {
+		r.logger.Info().
+			Str("denom", a.denom).
+			Float64("last_price", a.lastPrice).
+			Time("last_relay", a.lastRelay).
+			Msg("asset state at shutdown")
+	}
 }
 
 // Stop stops the relayer process and waits for it to gracefully exit.
 func (o *Relayer) Stop() {
 	o.closer.Close()
-	<-o.closer.Done()
+	if o.running.Load() {
+		<-o.stopped.Done()
+	}
+}
+
+// isLeader returns true if this replica is the leader and logs leadership
+// changes. The memory is cleared when the leadership is lost, so that a new
+// leadership starts with a full relay.
+func (r *Relayer) isLeader(ctx context.Context) bool {
+	leader, err := r.elector.IsLeader(ctx)
+	if err != nil {
+		r.logger.Err(err).Msg("unable to check leadership")
+	}
+
+	if leader != r.leader {
+		if leader {
+			r.logger.Info().Msg("became leader; relaying prices")
+			telemetry.SetGauge(1, "leader")
+		} else {
+			r.logger.Info().Msg("lost leadership; standing by")
+			telemetry.SetGauge(0, "leader")
+			r.latestAssets = []asset{}
+			r.window = batchWindow{}
+		}
+		r.leader = leader
+	}
+
+	return leader
+}
+
+// Reload schedules a new, validated config to be applied before the next tick.
+// A pending config that has not been applied yet is replaced.
+func (r *Relayer) Reload(cfg config.Config) {
+	for {
+		select {
+		case r.reloads <- cfg:
+			return
+		default:
+			// drop the pending config
+			select {
+			case <-r.reloads:
+			default:
+			}
+		}
+	}
+}
+
+// applyConfig applies the relay settings and assets of a reloaded config.
+// New assets are relayed on the next tick, removed assets are dropped and
+// the memory of the other assets is kept.
+func (r *Relayer) applyConfig(cfg config.Config) {
+	references, err := reference.NewSources(cfg.Assets)
+	if err != nil {
+		r.logger.Err(err).Msg("invalid reference source; keeping the current config")
+		return
+	}
+	policies, err := policy.NewPolicies(cfg.Assets, cfg.Relayer)
+	if err != nil {
+		r.logger.Err(err).Msg("invalid relay policy; keeping the current config")
+		return
+	}
+	feeds, err := composed.NewFeeds(cfg.Assets, cfg.Relayer.EVMRPC, r.relayerClient.RPCTimeout)
+	if err != nil {
+		r.logger.Err(err).Msg("invalid composed feed; keeping the current config")
+		return
+	}
+	if err := checkFreshness(cfg, r.resolveWindow, time.Now()); err != nil {
+		r.logger.Err(err).Msg("keeping the current config")
+		return
+	}
+	// keep the smoothing state of the assets whose policy is unchanged
+	for denom := range policies {
+		if old, ok := r.policies[denom]; ok && samePolicy(r.cfg, cfg, denom) {
+			policies[denom] = old
+		}
+	}
+	r.references = references
+	r.policies = policies
+	r.feeds = feeds
+	r.quorum = quorum.NewReader(r.logger, cfg.Quorum, r.relayerClient.RPCTimeout)
+	r.relayerClient.Retry = cfg.Retry
+	if !reflect.DeepEqual(cfg.LightClient, r.cfg.LightClient) {
+		// the verifier restarts from the new trusted header
+		r.closeVerifier()
+	}
+
+	if cfg.Account != r.cfg.Account || cfg.Keyring != r.cfg.Keyring || cfg.RPC != r.cfg.RPC ||
+		cfg.Gas != r.cfg.Gas || cfg.GasPrices != r.cfg.GasPrices || cfg.MaxGasPrices != r.cfg.MaxGasPrices ||
+		cfg.GasPriceBump != r.cfg.GasPriceBump || !reflect.DeepEqual(cfg.GasStrategy, r.cfg.GasStrategy) ||
+		cfg.Election != r.cfg.Election || cfg.Admin != r.cfg.Admin {
+		r.logger.Warn().Msg("account, keyring, rpc, gas, election and admin changes require a restart; ignoring them")
+		cfg.Account, cfg.Keyring, cfg.RPC = r.cfg.Account, r.cfg.Keyring, r.cfg.RPC
+		cfg.Gas, cfg.GasPrices = r.cfg.Gas, r.cfg.GasPrices
+		cfg.MaxGasPrices, cfg.GasPriceBump, cfg.GasStrategy = r.cfg.MaxGasPrices, r.cfg.GasPriceBump, r.cfg.GasStrategy
+		cfg.Election, cfg.Admin = r.cfg.Election, r.cfg.Admin
+	}
+
+	// before the first tick, init relays every asset of the new config
+	if len(r.latestAssets) > 0 {
+		latestAssets := make([]asset, 0, len(cfg.Assets))
+		for _, a := range cfg.Assets {
+			i := r.assetIndex(a.Denom)
+			if i < 0 {
+				// a zero last relay time triggers a heartbeat relay
+				r.logger.Info().Str("denom", a.Denom).Msg("asset added")
+				latestAssets = append(latestAssets, asset{denom: a.Denom})
+				continue
+			}
+			latestAssets = append(latestAssets, r.latestAssets[i])
+		}
+		for _, a := range r.latestAssets {
+			if !cfg.HasAsset(a.denom) {
+				r.logger.Info().Str("denom", a.denom).Msg("asset removed")
+			}
+		}
+		r.latestAssets = latestAssets
+	}
+
+	r.cfg = cfg
+	r.logger.Info().
+		Dur("interval", cfg.Relayer.Interval).
+		Float64("deviation", cfg.Relayer.Deviation).
+		Int("assets", len(cfg.Assets)).
+		Msg("config reloaded")
+}
+
+// samePolicy returns true if the relay policy of the given denom is the same
+// in both configs.
+func samePolicy(a, b config.Config, denom string) bool {
+	if a.Relayer.Interval != b.Relayer.Interval || a.Relayer.Deviation != b.Relayer.Deviation ||
+		a.Relayer.Schedule != b.Relayer.Schedule || a.Relayer.Jitter != b.Relayer.Jitter {
+		return false
+	}
+	var assetA, assetB config.Assets
+	for _, asset := range a.Assets {
+		if asset.Denom == denom {
+			assetA = asset
+		}
+	}
+	for _, asset := range b.Assets {
+		if asset.Denom == denom {
+			assetB = asset
+		}
+	}
+	return reflect.DeepEqual(assetA.Policy, assetB.Policy) && assetA.Smoothing == assetB.Smoothing
+}
+
+// assetIndex returns the index of the given denom in memory, or -1.
+func (r *Relayer) assetIndex(denom string) int {
+	for i, a := range r.latestAssets {
+		if a.denom == denom {
+			return i
+		}
+	}
+	return -1
 }
 
 // init initializes the relayer by submitting a relay for
 // each asset in the config and setting the latest price info
-// in memory.
+// in memory. Assets failing the circuit breaker checks are halted and
+// relayed by a heartbeat once their halt is cleared. Assets whose price
+// cannot be read are relayed by a heartbeat once a read succeeds.
 func (r *Relayer) init(ctx context.Context) error {
-	latestAssets := make([]asset, len(r.cfg.Assets))
+	r.latestAssets = make([]asset, len(r.cfg.Assets))
 	batch := []string{}
 	for k, v := range r.cfg.Assets {
-		// Get price
-		price, err := r.relayerClient.GetPrice(ctx, v.Denom)
-		if err != nil {
-			r.logger.Err(err).Msg("unable to communicate with ojo node")
-			return err
+		r.latestAssets[k] = asset{denom: v.Denom}
+		if r.breaker.isHalted(v.Denom) {
+			continue
 		}
-		priceFl, err := price.Amount.Float64()
+
+		// Get price
+		priceFl, err := r.getPrice(ctx, v.Denom)
 		if err != nil {
-			r.logger.Err(err).Msg("unable to convert price to float64")
-			return err
+			r.priceFailed(k, err, time.Now())
+			continue
 		}
-
-		// Set latest update in memory
-		latestAssets[k] = asset{
-			lastPrice: priceFl,
-			lastRelay: time.Now(),
-			denom:     v.Denom,
+		if reason := checkPrice(v, 0, priceFl); reason != "" {
+			r.halt(v.Denom, priceFl, reason)
+			continue
 		}
+		r.latestAssets[k].lastObserved = priceFl
+		// read the rate of the composed feed, if any, as of the relay
+		r.composedRates(ctx, r.latestAssets[k], time.Now())
 
 		// Add to batch
 		batch = append(batch, v.Denom)
 	}
 
+	batch = r.dropMismatched(ctx, r.dropStale(ctx, batch))
+	if len(batch) == 0 {
+		r.logger.Warn().Msg("every asset is halted, refused or unreadable; no initial relay")
+		return nil
+	}
+
 	// Relay price
-	if err := r.relay(batch); err != nil {
+	if err := r.relay(ctx, batch); err != nil {
 		r.logger.Err(err).Msg("unable to submit initial relays")
+		r.latestAssets = []asset{}
 		return err
 	}
 
 	// Set to memory
-	r.latestAssets = latestAssets
+	for _, denom := range batch {
+		i := r.assetIndex(denom)
+		r.latestAssets[i].lastPrice = r.latestAssets[i].lastObserved
+		r.latestAssets[i].lastRelay = time.Now()
+		r.latestAssets[i].lastRate = r.currentRate(denom)
+	}
 	return nil
 }
 
-// updateMemory takes a set of denoms and updates the memory with the latest price
-// and timestamp.
-func (r *Relayer) updateMemory(ctx context.Context, denoms []string) error {
+// updateMemory takes a set of relayed denoms and updates the memory with the
+// latest price and timestamp. If the latest price cannot be read, the price
+// observed in the tick is kept.
+func (r *Relayer) updateMemory(ctx context.Context, denoms []string) {
 	for _, v := range denoms {
+		i := r.assetIndex(v)
+		if i < 0 {
+			continue
+		}
 		price, err := r.getPrice(ctx, v)
 		if err != nil {
-			r.logger.Err(err).Msg("unable to communicate with ojo node")
-			return err
+			r.logger.Err(err).Str("denom", v).Msg("unable to get relayed price; keeping the observed one")
+			price = r.latestAssets[i].lastObserved
 		}
 
-		for k, a := range r.latestAssets {
-			if a.denom == v {
-				r.latestAssets[k].lastPrice = price
-				r.latestAssets[k].lastRelay = time.Now()
-			}
-		}
+		r.latestAssets[i].lastPrice = price
+		r.latestAssets[i].lastRelay = time.Now()
+		r.latestAssets[i].lastRate = r.currentRate(v)
 	}
-
-	return nil
 }
 
 func (r *Relayer) tick(ctx context.Context) error {
@@ -163,40 +478,118 @@ func (r *Relayer) tick(ctx context.Context) error {
 
 	// denomsBatch is a slice of denoms that we need to relay
 	batch := []string{}
-
-	// if not, check for heartbeats and deviations
-	for _, v := range r.latestAssets {
-		// if heartbeat needs to be sent, relay
-		if heartbeat(r.cfg.Relayer.Interval, v.lastRelay) {
-			batch = append(batch, v.denom)
-			r.logger.Info().Str("denom", v.denom).Msg("heartbeat relay")
+	// candidates may join the batch if a relay goes out anyway
+	candidates := []candidate{}
+	// urgent is true if a deviation above the urgent threshold triggered
+	urgent := false
+
+	// if not, check the relay policy of every asset
+	for i, v := range r.latestAssets {
+		if r.breaker.isHalted(v.denom) || v.backingOff(time.Now()) {
 			continue
 		}
 
-		// if price has deviated, send a relay
+		// every price goes through the circuit breaker, even without relay,
+		// so that single steps are measured between consecutive ticks. A
+		// failed read only leaves its asset out of this tick.
 		price, err := r.getPrice(ctx, v.denom)
 		if err != nil {
-			r.logger.Err(err).Msg("unable to communicate with ojo node")
-			return err
+			r.priceFailed(i, err, time.Now())
+			continue
 		}
-		pct, dev := deviated(v.lastPrice, price, r.cfg.Relayer.Deviation)
-		if dev {
+		r.priceRecovered(i)
+		if reason := checkPrice(r.assetBounds(v.denom), v.lastObserved, price); reason != "" {
+			r.halt(v.denom, price, reason)
+			continue
+		}
+		r.latestAssets[i].lastObserved = price
+
+		// ask the relay policy of the asset
+		p, ok := r.policies[v.denom]
+		if !ok {
+			if p, err = policy.Default(r.cfg.Relayer); err != nil {
+				return err
+			}
+		}
+		// with a composed feed, the policy decides on the value consumers
+		// read, the price times the on-chain rate
+		now := time.Now()
+		history := policy.History{Denom: v.denom, LastPrice: v.lastPrice, LastRelay: v.lastRelay}
+		value := price
+		if rate, lastRate, ok := r.composedRates(ctx, v, now); ok {
+			history.LastPrice *= lastRate
+			value *= rate
+		}
+		d := p.Decide(history, value, now)
+		if !d.Relay && r.safetyDue(v, now) {
+			// relayed right away, without waiting in the batching window
+			d.Relay, d.Reason = true, "safety: the last price would go stale on the destination"
+			telemetry.IncrCounter(1, "relay", "safety")
+			urgent = true
+		}
+		r.latestAssets[i].threshold = d.Threshold
+		telemetry.SetGaugeWithLabels([]string{"threshold"}, float32(d.Threshold), []metrics.Label{
+			telemetry.NewLabel("denom", v.denom),
+		})
+		if d.Relay {
 			batch = append(batch, v.denom)
 			r.logger.Info().Str("denom", v.denom).
 				Float64("last_updated_price", v.lastPrice).
 				Float64("new_price", price).
-				Float64("deviation_percentage", pct).
-				Float64("deviation_threshold", r.cfg.Relayer.Deviation).
-				Msg("deviation relay")
+				Str("reason", d.Reason).
+				Msg("relay triggered")
+			if u := r.cfg.Relayer.UrgentDeviation; u > 0 && policy.Change(history.LastPrice, value) >= u {
+				urgent = true
+			}
+		} else if c, ok := piggybackCandidate(r.cfg.Relayer, v.denom, history.LastPrice, value, d, now); ok {
+			candidates = append(candidates, c)
+		}
+	}
+
+	// both legs of a quoted pair are relayed together, so that their
+	// resolve times match
+	for _, denom := range r.pairTriggers(time.Now()) {
+		if !slices.Contains(batch, denom) {
+			batch = append(batch, denom)
+		}
+	}
+
+	// triggered assets wait in the batching window for other triggers
+	height := int64(0)
+	if r.cfg.Relayer.BatchWindowBlocks > 0 {
+		h, err := r.relayerClient.ChainHeight.GetChainHeight()
+		if err != nil {
+			return err
+		}
+		height = h
+	}
+	batch = r.collect(batch, urgent, time.Now(), height)
+
+	batch = r.dropMismatched(ctx, r.dropStale(ctx, batch))
+
+	// assets close to their threshold or heartbeat join a relay that goes
+	// out anyway, which costs less than relaying them on their own later
+	joined := []string{}
+	if len(batch) > 0 && len(candidates) > 0 {
+		joined = capBatch(batch, r.dropMismatched(ctx, r.dropStale(ctx, piggyback(batch, candidates))), r.cfg.Relayer.MaxBatch)
+		if len(joined) > 0 {
+			r.logger.Info().Strs("denoms", joined).Msg("piggybacking on relay")
 		}
+		batch = append(batch, joined...)
 	}
 
-	// batch relays and then update memory
-	if len(batch) > 0 {
-		if err := r.relay(batch); err != nil {
+	// batch relays and then update memory; triggered assets trigger again
+	// once relays resume
+	if len(batch) > 0 && r.paused(time.Now()) {
+		r.logger.Debug().Strs("denoms", batch).Time("paused_until", r.pausedUntil).Msg("relays paused")
+	} else if len(batch) > 0 {
+		res, err := r.Relay(ctx, batch)
+		if err != nil {
 			r.logger.Err(err).Msg("unable to relay price")
 			return err
 		}
+		fee, _ := res.Fee.Amount.ToLegacyDec().Float64()
+		recordPiggyback(joined, fee, res.Fee.Denom)
 		r.updateMemory(ctx, batch)
 	} else {
 		r.logger.Debug().Msg("no relays necessary")
@@ -205,43 +598,43 @@ func (r *Relayer) tick(ctx context.Context) error {
 	return nil
 }
 
-// heartbeat checks the time since last relay and returns true if we need to relay.
-func heartbeat(interval time.Duration, lastUpdate time.Time) bool {
-	return time.Since(lastUpdate) >= interval
+// RelayResult defines the outcome of a successfully broadcasted relay tx.
+type RelayResult struct {
+	TxResponse *sdk.TxResponse
+	Fee        sdk.Coin
 }
 
-// deviated checks if the price has deviated from the last price by the deviation %.
-func deviated(existingPrice float64, newestPrice float64, threshold float64) (float64, bool) {
-	if existingPrice == 0 {
-		return 0, false
-	}
-
-	// calculate the deviation percentage between price and lastPrice
-	deviationPct := (newestPrice - existingPrice) / existingPrice
-
-	// get absolute value
-	if deviationPct < 0 {
-		deviationPct *= -1
-	}
-
-	return deviationPct, deviationPct >= threshold
+// relay sends a relay message to the Ojo node.
+func (r *Relayer) relay(ctx context.Context, denoms []string) error {
+	_, err := r.Relay(ctx, denoms)
+	return err
 }
 
-// relay sends a relay message to the Ojo node.
-func (r Relayer) relay(denoms []string) error {
+// Relay sends the given denoms to the Ojo node in a single tx, regardless of
+// heartbeats and deviations, and returns the broadcasted tx and the gas fee
+// paid to axelar. The denoms are relayed in one message per callback, each
+// paying the estimated gas fee.
+func (r *Relayer) Relay(ctx context.Context, denoms []string) (RelayResult, error) {
+	if r.paused(time.Now()) {
+		return RelayResult{}, fmt.Errorf("%w until %s", ErrPaused, r.pausedUntil.Format(time.RFC3339))
+	}
 	r.logger.Info().Strs("denoms", denoms).Msg("submitting relay tx")
 
-	gasFee, err := client.EstimateGasFee(
-		r.cfg.Relayer.Destination,
-		r.cfg.Relayer.Contract,
-		r.cfg.AxelarGas.Default,
-		r.cfg.AxelarGas.Multiplier,
-	)
+	var gasFee math.Int
+	err := retry.Do(ctx, "fee_estimate", r.cfg.Retry.FeeEstimate, func(context.Context) (err error) {
+		gasFee, err = client.EstimateGasFee(
+			r.cfg.Relayer.Destination,
+			r.cfg.Relayer.Contract,
+			r.cfg.AxelarGas.Default,
+			r.cfg.AxelarGas.Multiplier,
+		)
+		return err
+	})
 	if err != nil {
 		r.logger.Err(err).Str("default", r.cfg.AxelarGas.Default).Msg("unable to estimate gas fee")
 		defaultGasFee, ok := math.NewIntFromString(r.cfg.AxelarGas.Default)
 		if !ok {
-			return fmt.Errorf("unable to convert default gas fee to int")
+			return RelayResult{}, fmt.Errorf("unable to convert default gas fee to int")
 		}
 		gasFee = defaultGasFee
 	}
@@ -251,27 +644,71 @@ func (r Relayer) relay(denoms []string) error {
 	}
 	r.logger.Info().Strs("gas_fee", []string{coins.String()}).Msg("estimated gas fee")
 
-	msg := gmptypes.NewMsgRelay(
-		r.cfg.Account.Address,
-		r.cfg.Relayer.Destination,
-		r.cfg.Relayer.Contract,
-		"0x001",           // ojo contract address - empty
-		coins,             // tokens we're paying with
-		denoms,            // tokens we're relaying
-		[]byte{},          // command selector - empty
-		[]byte{},          // params - empty, no callback
-		time.Now().Unix(), // unix timestamp
-	)
-	currentHeight, err := r.relayerClient.ChainHeight.GetChainHeight()
+	// every message of the tx has the same resolve time
+	timestamp := time.Now().Unix()
+	groups := r.groupByCallback(denoms)
+	msgs := make([]sdk.Msg, 0, len(groups))
+	for _, g := range groups {
+		contract, selector, params, err := callbackArgs(g.callback)
+		if err != nil {
+			return RelayResult{}, fmt.Errorf("invalid callback of %v: %w", g.denoms, err)
+		}
+		msgs = append(msgs, gmptypes.NewMsgRelay(
+			r.cfg.Account.Address,
+			r.cfg.Relayer.Destination,
+			r.cfg.Relayer.Contract,
+			contract,  // contract called back, if any
+			coins,     // tokens we're paying with
+			g.denoms,  // tokens we're relaying
+			selector,  // command selector - empty without callback
+			params,    // command params
+			timestamp, // unix timestamp
+		))
+	}
+	resp, err := r.relayerClient.BroadcastTx(ctx, msgs...)
 	if err != nil {
-		return err
+		if errors.Is(err, client.ErrInsufficientFunds) {
+			r.pauseRelays(err)
+		}
+		return RelayResult{}, err
 	}
 
-	return r.relayerClient.BroadcastTx(currentHeight, broadcastTimeout, msg)
+	return RelayResult{
+		TxResponse: resp,
+		Fee:        sdk.NewCoin(coins.Denom, coins.Amount.MulRaw(int64(len(msgs)))),
+	}, nil
 }
 
 // getPrice is a util function to get the price of a given denom as a float64.
-func (r Relayer) getPrice(ctx context.Context, denom string) (float64, error) {
+// With quorum reads, the price is the one agreed on by the quorum nodes at
+// the block before the latest one, which every node should have committed.
+// Failed reads are retried as the price query retry policy defines, unless
+// the node has no price for the denom.
+func (r *Relayer) getPrice(ctx context.Context, denom string) (float64, error) {
+	var price float64
+	err := retry.Do(ctx, "price_query", r.cfg.Retry.PriceQuery, func(ctx context.Context) (err error) {
+		price, err = r.readPrice(ctx, denom)
+		if errors.Is(err, client.ErrPriceNotFound) {
+			return retry.Permanent(err)
+		}
+		return err
+	})
+	return price, err
+}
+
+// readPrice reads the price of a given denom once.
+func (r *Relayer) readPrice(ctx context.Context, denom string) (float64, error) {
+	if r.cfg.LightClient.Enabled() {
+		return r.getVerifiedPrice(ctx, denom)
+	}
+	if r.quorum != nil {
+		height, err := r.relayerClient.ChainHeight.GetChainHeight()
+		if err != nil {
+			return 0, err
+		}
+		return r.quorum.Price(ctx, denom, height-1)
+	}
+
 	price, err := r.relayerClient.GetPrice(ctx, denom)
 	if err != nil {
 		return 0, err
@@ -279,3 +716,44 @@ func (r Relayer) getPrice(ctx context.Context, denom string) (float64, error) {
 
 	return price.Amount.Float64()
 }
+
+// getVerifiedPrice gets the price of a given denom as of the block before the
+// latest one, verified with the light client. Unverifiable prices are
+// rejected.
+func (r *Relayer) getVerifiedPrice(ctx context.Context, denom string) (float64, error) {
+	if r.verifier == nil {
+		verifier, err := lightclient.NewVerifier(
+			ctx,
+			r.cfg.LightClient,
+			r.cfg.Account.ChainID,
+			r.cfg.RPC.TMRPCEndpoint,
+			r.relayerClient.RPCTimeout,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("unable to start the light client: %w", err)
+		}
+		r.verifier = verifier
+	}
+
+	height, err := r.relayerClient.ChainHeight.GetChainHeight()
+	if err != nil {
+		return 0, err
+	}
+	price, err := r.verifier.ExchangeRate(ctx, denom, height-1)
+	if err != nil {
+		telemetry.IncrCounter(1, "failure", "verify")
+		return 0, err
+	}
+	return price.Float64()
+}
+
+// closeVerifier closes the light client, if it was started.
+func (r *Relayer) closeVerifier() {
+	if r.verifier == nil {
+		return
+	}
+	if err := r.verifier.Close(); err != nil {
+		r.logger.Err(err).Msg("unable to close the light client")
+	}
+	r.verifier = nil
+}
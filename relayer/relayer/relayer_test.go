@@ -1,8 +1,14 @@
 package relayer
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/client"
+	"github.com/ojo-network/ojo-evm/relayer/relayer/policy"
+	"github.com/rs/zerolog"
 )
 
 func TestHeartbeat(t *testing.T) {
@@ -34,8 +40,9 @@ func TestHeartbeat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := heartbeat(tt.interval, tt.lastUpdate); got != tt.want {
-				t.Errorf("heartbeat() = %v, want %v", got, tt.want)
+			h := policy.History{LastPrice: 1, LastRelay: tt.lastUpdate}
+			if got := (policy.Heartbeat{Interval: tt.interval}).Decide(h, 1, time.Now()).Relay; got != tt.want {
+				t.Errorf("Heartbeat.Decide() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -94,13 +101,114 @@ func TestDeviated(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotDev, gotBool := deviated(tt.price, tt.newPrice, tt.threshold)
-			if gotDev != tt.wantDev {
-				t.Errorf("deviated() gotDev = %v, want %v", gotDev, tt.wantDev)
+			if gotDev := policy.Change(tt.price, tt.newPrice); gotDev != tt.wantDev {
+				t.Errorf("Change() = %v, want %v", gotDev, tt.wantDev)
 			}
-			if gotBool != tt.wantBool {
-				t.Errorf("deviated() gotBool = %v, want %v", gotBool, tt.wantBool)
+			h := policy.History{LastPrice: tt.price, LastRelay: time.Now()}
+			if gotBool := (policy.Deviation{Threshold: tt.threshold}).Decide(h, tt.newPrice, time.Now()).Relay; gotBool != tt.wantBool {
+				t.Errorf("Deviation.Decide() = %v, want %v", gotBool, tt.wantBool)
 			}
 		})
 	}
 }
+
+func TestApplyConfig(t *testing.T) {
+	cfg := config.Config{
+		Relayer: config.Relayer{Interval: time.Hour, Deviation: 0.05},
+		Assets:  []config.Assets{{Denom: "BTC"}, {Denom: "ETH"}},
+	}
+	r, err := New(zerolog.Nop(), client.RelayerClient{}, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	lastRelay := time.Now()
+	r.latestAssets = []asset{
+		{denom: "BTC", lastPrice: 100, lastRelay: lastRelay},
+		{denom: "ETH", lastPrice: 10, lastRelay: lastRelay},
+	}
+
+	reloaded := cfg
+	reloaded.Relayer.Deviation = 0.01
+	reloaded.Assets = []config.Assets{{Denom: "ETH"}, {Denom: "ATOM"}}
+	r.applyConfig(reloaded)
+
+	if r.cfg.Relayer.Deviation != 0.01 {
+		t.Errorf("applyConfig() deviation = %v, want %v", r.cfg.Relayer.Deviation, 0.01)
+	}
+	want := []asset{
+		{denom: "ETH", lastPrice: 10, lastRelay: lastRelay},
+		{denom: "ATOM"},
+	}
+	if len(r.latestAssets) != len(want) {
+		t.Fatalf("applyConfig() assets = %v, want %v", r.latestAssets, want)
+	}
+	for i := range want {
+		if r.latestAssets[i] != want[i] {
+			t.Errorf("applyConfig() asset = %v, want %v", r.latestAssets[i], want[i])
+		}
+	}
+	// the new asset is relayed on the next heartbeat check
+	h := policy.History{Denom: "ATOM", LastRelay: r.latestAssets[1].lastRelay}
+	if !r.policies["ATOM"].Decide(h, 1, time.Now()).Relay {
+		t.Errorf("applyConfig() new asset does not trigger a heartbeat")
+	}
+}
+
+func TestStartStop(t *testing.T) {
+	newRelayer := func() *Relayer {
+		cfg := config.Config{
+			Relayer: config.Relayer{Interval: time.Hour, Deviation: 0.05},
+			Assets:  []config.Assets{{Denom: "BTC"}},
+		}
+		r, err := New(zerolog.Nop(), client.RelayerClient{}, cfg)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		return r
+	}
+
+	t.Run("context cancellation", func(t *testing.T) {
+		r := newRelayer()
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() { errCh <- r.Start(ctx) }()
+
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-errCh:
+			if err != nil {
+				t.Errorf("Start() error = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Start() did not return after cancellation")
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		r := newRelayer()
+		errCh := make(chan error, 1)
+		go func() { errCh <- r.Start(context.Background()) }()
+
+		time.Sleep(100 * time.Millisecond)
+		stopped := make(chan struct{})
+		go func() {
+			r.Stop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Stop() did not return")
+		}
+		if err := <-errCh; err != nil {
+			t.Errorf("Start() error = %v", err)
+		}
+	})
+
+	t.Run("stop without start", func(_ *testing.T) {
+		newRelayer().Stop()
+	})
+}
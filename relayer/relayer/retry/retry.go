@@ -0,0 +1,123 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/hashicorp/go-metrics"
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+// permanentError marks an error that is not retried.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the given error as not retryable, e.g. a price that does
+// not exist or a rejected tx.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// immediateError marks an error that is retried without waiting.
+type immediateError struct {
+	err error
+}
+
+func (e immediateError) Error() string {
+	return e.err.Error()
+}
+
+func (e immediateError) Unwrap() error {
+	return e.err
+}
+
+// Immediate marks the given error as retried right away, e.g. once the cause
+// of the failure was remediated. The retry still counts as an attempt.
+func Immediate(err error) error {
+	if err == nil {
+		return nil
+	}
+	return immediateError{err: err}
+}
+
+// Retryable returns false if the given error was marked as permanent or is
+// the error of a done context.
+func Retryable(err error) bool {
+	var permanent permanentError
+	return !errors.As(err, &permanent) && !errors.Is(err, context.Canceled)
+}
+
+// Backoff returns the wait before the given retry, 1 for the first one. The
+// wait doubles from the base of the policy up to its max, and is randomized
+// by up to its jitter.
+func Backoff(cfg config.Retry, retry int) time.Duration {
+	backoff := cfg.Base
+	for i := 1; i < retry && backoff < cfg.Max; i++ {
+		backoff *= 2
+	}
+	if cfg.Max > 0 && backoff > cfg.Max {
+		backoff = cfg.Max
+	}
+	if cfg.Jitter > 0 {
+		backoff += time.Duration(float64(backoff) * cfg.Jitter * (2*rand.Float64() - 1))
+	}
+	return backoff
+}
+
+// Do calls fn until it succeeds, returns a non retryable error, was called
+// MaxAttempts times or ctx is done, waiting between the calls as the policy
+// defines. It returns the last error of fn, unwrapped if it was permanent or
+// immediate. Retries are counted in the "retry" metric by operation, and
+// calls failing after every attempt in the "retry.exhausted" metric.
+func Do(ctx context.Context, op string, cfg config.Retry, fn func(ctx context.Context) error) error {
+	labels := []metrics.Label{telemetry.NewLabel("op", op)}
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		var permanent permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		var immediate immediateError
+		wait := !errors.As(err, &immediate)
+		if !wait {
+			err = immediate.err
+		}
+		if !Retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		if attempt >= cfg.MaxAttempts {
+			if cfg.MaxAttempts > 1 {
+				telemetry.IncrCounterWithLabels([]string{"retry", "exhausted"}, 1, labels)
+			}
+			return err
+		}
+
+		telemetry.IncrCounterWithLabels([]string{"retry"}, 1, labels)
+		if !wait {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(Backoff(cfg, attempt)):
+		}
+	}
+}
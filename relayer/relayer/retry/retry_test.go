@@ -0,0 +1,104 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojo-network/ojo-evm/relayer/config"
+)
+
+func TestBackoff(t *testing.T) {
+	cfg := config.Retry{Base: time.Second, Max: 5 * time.Second}
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{retry: 1, want: time.Second},
+		{retry: 2, want: 2 * time.Second},
+		{retry: 3, want: 4 * time.Second},
+		{retry: 4, want: 5 * time.Second},
+		{retry: 50, want: 5 * time.Second},
+	}
+	for _, tc := range tests {
+		if got := Backoff(cfg, tc.retry); got != tc.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tc.retry, got, tc.want)
+		}
+	}
+
+	cfg.Jitter = 0.2
+	for i := 0; i < 100; i++ {
+		if got := Backoff(cfg, 1); got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("Backoff() with jitter = %s, want within 20%% of 1s", got)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	errFailed := errors.New("failed")
+	cfg := config.Retry{Base: time.Millisecond, Max: time.Millisecond, MaxAttempts: 3}
+
+	tests := []struct {
+		name         string
+		cfg          config.Retry
+		failures     int
+		err          error
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "success", cfg: cfg, wantAttempts: 1},
+		{name: "success after retries", cfg: cfg, failures: 2, err: errFailed, wantAttempts: 3},
+		{name: "exhausted", cfg: cfg, failures: 5, err: errFailed, wantErr: errFailed, wantAttempts: 3},
+		{name: "permanent", cfg: cfg, failures: 5, err: Permanent(errFailed), wantErr: errFailed, wantAttempts: 1},
+		{name: "immediate", cfg: cfg, failures: 5, err: Immediate(errFailed), wantErr: errFailed, wantAttempts: 3},
+		{name: "no retries", cfg: config.Retry{}, failures: 5, err: errFailed, wantErr: errFailed, wantAttempts: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			err := Do(context.Background(), "test", tc.cfg, func(context.Context) error {
+				attempts++
+				if attempts <= tc.failures {
+					return tc.err
+				}
+				return nil
+			})
+			if err != tc.wantErr {
+				t.Errorf("Do() error = %v, want %v", err, tc.wantErr)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("Do() attempts = %d, want %d", attempts, tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDoImmediate(t *testing.T) {
+	start := time.Now()
+	attempts := 0
+	err := Do(context.Background(), "test", config.Retry{Base: time.Hour, Max: time.Hour, MaxAttempts: 2}, func(context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return Immediate(errors.New("failed"))
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 || time.Since(start) > time.Minute {
+		t.Errorf("Do() error = %v after %d attempts in %s, want success after 2 immediate attempts",
+			err, attempts, time.Since(start))
+	}
+}
+
+func TestDoCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := Do(ctx, "test", config.Retry{Base: time.Hour, Max: time.Hour, MaxAttempts: 3}, func(context.Context) error {
+		attempts++
+		cancel()
+		return errors.New("failed")
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Do() error = %v after %d attempts, want an error after 1 attempt", err, attempts)
+	}
+}
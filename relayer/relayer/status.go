@@ -0,0 +1,69 @@
+package relayer
+
+import "time"
+
+type (
+	// Status defines a snapshot of the state of the relayer.
+	Status struct {
+		Leader bool          `json:"leader"`
+		Assets []AssetStatus `json:"assets"`
+		Halts  []Halt        `json:"halts"`
+		// PausedUntil is set while relays are paused, e.g. without funds.
+		PausedUntil *time.Time `json:"paused_until,omitempty"`
+	}
+
+	// AssetStatus defines the state of a relayed asset.
+	AssetStatus struct {
+		Denom     string    `json:"denom"`
+		LastPrice float64   `json:"last_price"`
+		LastRelay time.Time `json:"last_relay"`
+		// Threshold is the effective deviation threshold of the relay policy.
+		Threshold float64 `json:"threshold"`
+		Halted    bool    `json:"halted"`
+		// Failures counts the consecutive failed price reads, the last one
+		// failing with LastError.
+		Failures  int    `json:"failures"`
+		LastError string `json:"last_error,omitempty"`
+	}
+)
+
+// Status returns the state of the relayer as of its last tick. Halts are
+// always up to date.
+func (r *Relayer) Status() Status {
+	r.statusMtx.Lock()
+	status := r.status
+	status.Assets = append([]AssetStatus{}, r.status.Assets...)
+	r.statusMtx.Unlock()
+
+	status.Halts = r.breaker.halts()
+	for i, a := range status.Assets {
+		status.Assets[i].Halted = r.breaker.isHalted(a.Denom)
+	}
+	return status
+}
+
+// publishStatus snapshots the memory of the relayer for Status, as the
+// memory is only accessed by the relayer loop.
+func (r *Relayer) publishStatus() {
+	assets := make([]AssetStatus, len(r.latestAssets))
+	for i, a := range r.latestAssets {
+		assets[i] = AssetStatus{
+			Denom:     a.denom,
+			LastPrice: a.lastPrice,
+			LastRelay: a.lastRelay,
+			Threshold: a.threshold,
+			Failures:  a.failures,
+		}
+		if a.lastError != nil {
+			assets[i].LastError = a.lastError.Error()
+		}
+	}
+
+	r.statusMtx.Lock()
+	defer r.statusMtx.Unlock()
+	r.status = Status{Leader: r.leader, Assets: assets}
+	if r.paused(time.Now()) {
+		until := r.pausedUntil
+		r.status.PausedUntil = &until
+	}
+}